@@ -11,6 +11,11 @@ type RoutingContext struct {
 	RoutePath    string
 	RoutePattern string
 	RouteParams  map[string]interface{}
+
+	// TrailingSlash is set by routers accepting the trailing
+	// slash of the RoutePath as canonical, so handlers don't
+	// need to redirect
+	TrailingSlash bool
 }
 
 // Clone() creates a copy of a RoutingContext object
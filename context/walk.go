@@ -31,11 +31,31 @@ func (rctx *RoutingContext) Init(prefix, path string) {
 }
 
 func (rctx *RoutingContext) Step(prefix string, args map[string]string) *RoutingContext {
+	var values map[string]interface{}
+
+	if len(args) > 0 {
+		values = make(map[string]interface{}, len(args))
+		for k, v := range args {
+			values[k] = v
+		}
+	}
+
+	return rctx.StepValues(prefix, values)
+}
+
+// StepValues is like Step but taking typed values, like the ones
+// produced by capture constraints
+func (rctx *RoutingContext) StepValues(prefix string, args map[string]interface{}) *RoutingContext {
 	var path string
 
 	pattern := strings.TrimSuffix(rctx.RoutePattern, "/*")
 
-	if prefix == rctx.RoutePath {
+	if prefix == "" {
+		// nothing consumed, like {path...} at the root
+		pattern += "/*"
+		prefix = rctx.RoutePrefix
+		path = rctx.RoutePath
+	} else if prefix == rctx.RoutePath {
 		// prefix is the whole RoutePath
 		pattern += prefix
 		prefix = rctx.Path()
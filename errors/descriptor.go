@@ -29,6 +29,11 @@ type ErrorDescriptor struct {
 	Fatal    error          `json:"fatal,omitempty"`
 	Err      []error        `json:"error,omitempty"`
 	Stack    []errors.Frame `json:"stack,omitempty"`
+	ID       string         `json:"id,omitempty"` // correlation id, if reported
+
+	// for problem+json
+	Type       string                 `json:"-"`
+	Extensions map[string]interface{} `json:"-"`
 }
 
 func (desc *ErrorDescriptor) Status() int {
@@ -62,11 +67,14 @@ func (desc *ErrorDescriptor) TryServeHTTP(rw http.ResponseWriter, req *http.Requ
 
 // Serve Error as HTTP Response
 func (desc *ErrorDescriptor) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	// Exposure
+	desc = desc.exposed(req)
+
 	// Status
 	code := desc.Status()
 
 	// Content-Type
-	supported := []string{"text/plain", "application/json"}
+	supported := []string{"text/plain", "text/html", "application/json", ProblemMimeType}
 	mimetype := mimeparse.BestMatch(supported, req.Header.Get("Accept"))
 	if mimetype == "" {
 		mimetype = supported[0]
@@ -84,6 +92,18 @@ func (desc *ErrorDescriptor) ServeHTTP(rw http.ResponseWriter, req *http.Request
 	case CodeIsRedirect(code):
 		// redirect
 		tools.SetHeader(hdr, "Location", desc.Location)
+
+		if mimetype == "text/html" {
+			var buf bytes.Buffer
+
+			if err := HTML.Render(&buf, desc); err == nil {
+				tools.SetHeader(hdr, "Content-Type", "text/html; charset=utf-8")
+				rw.WriteHeader(code)
+				buf.WriteTo(rw)
+				return
+			}
+		}
+
 		rw.WriteHeader(code)
 
 		fmt.Fprintf(rw, "Redirected to %s", desc.Location)
@@ -110,6 +130,16 @@ func (desc *ErrorDescriptor) ServeHTTP(rw http.ResponseWriter, req *http.Request
 			if err == nil {
 				buf = bytes.NewBuffer(b)
 			}
+		case ProblemMimeType:
+			var b []byte
+			b, err = desc.renderProblem(req.URL.Path)
+			if err == nil {
+				buf = bytes.NewBuffer(b)
+			}
+		case "text/html":
+			var b []byte
+			buf = bytes.NewBuffer(b)
+			err = HTML.Render(buf, desc)
 		case "text/plain":
 			var b []byte
 			buf = bytes.NewBuffer(b)
@@ -117,11 +147,9 @@ func (desc *ErrorDescriptor) ServeHTTP(rw http.ResponseWriter, req *http.Request
 		}
 
 		if err != nil {
-			// render error
-			if buf != nil {
-				log.Printf("%+v: %q", errors.Here(), buf.String())
-			}
-			log.Panic(err)
+			// render error, the status is already out
+			log.Printf("%+v: %s", errors.Here(), err)
+			fmt.Fprintln(rw, ErrorText(code))
 		} else if buf != nil {
 			_, err = io.Copy(rw, buf)
 			if err != nil {
@@ -166,6 +194,12 @@ func (desc *ErrorDescriptor) renderTXT(w io.Writer) error {
 		}
 	}
 
+	// Reference
+	if desc.ID != "" {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "Reference:", desc.ID)
+	}
+
 	return nil
 }
 
@@ -230,6 +264,11 @@ func AsDescriptor(err web.Error) *ErrorDescriptor {
 		desc.Location = loc
 	}
 
+	// Problem details
+	if p, ok := err.(Problemer); ok {
+		desc.Type, desc.Extensions = p.Problem()
+	}
+
 	if p, ok := err.(interface {
 		Recovered() error
 	}); ok {
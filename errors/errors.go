@@ -35,6 +35,9 @@ func HandleMiddlewareError(w http.ResponseWriter, r *http.Request, err error, ne
 			return
 		}
 
+		// tell the observers
+		Observe(r, err)
+
 		// does the error know how to render itself?
 		h, ok := err.(http.Handler)
 		if !ok || h == nil {
@@ -1,11 +1,14 @@
 package errors
 
-//go:generate ./errorstack.sh BadRequest NotAcceptable
+//go:generate ./errorstack.sh BadRequest NotAcceptable Unauthorized Forbidden Conflict Gone PreconditionFailed RequestEntityTooLarge UnsupportedMediaType RequestedRangeNotSatisfiable TooManyRequests ServiceUnavailable
 
 import (
 	"net/http"
+	"strings"
+	"time"
 
 	"go.sancus.dev/core/errors"
+	"go.sancus.dev/web"
 	"go.sancus.dev/web/tools"
 )
 
@@ -14,6 +17,26 @@ var (
 	ErrBadRequest = &HandlerError{Code: http.StatusBadRequest}
 	// Constant http.StatusNotAcceptable HandlerError
 	ErrNotAcceptable = &HandlerError{Code: http.StatusNotAcceptable}
+	// Constant http.StatusUnauthorized HandlerError
+	ErrUnauthorized = &HandlerError{Code: http.StatusUnauthorized}
+	// Constant http.StatusForbidden HandlerError
+	ErrForbidden = &HandlerError{Code: http.StatusForbidden}
+	// Constant http.StatusConflict HandlerError
+	ErrConflict = &HandlerError{Code: http.StatusConflict}
+	// Constant http.StatusGone HandlerError
+	ErrGone = &HandlerError{Code: http.StatusGone}
+	// Constant http.StatusPreconditionFailed HandlerError
+	ErrPreconditionFailed = &HandlerError{Code: http.StatusPreconditionFailed}
+	// Constant http.StatusRequestEntityTooLarge HandlerError
+	ErrRequestEntityTooLarge = &HandlerError{Code: http.StatusRequestEntityTooLarge}
+	// Constant http.StatusUnsupportedMediaType HandlerError
+	ErrUnsupportedMediaType = &HandlerError{Code: http.StatusUnsupportedMediaType}
+	// Constant http.StatusRequestedRangeNotSatisfiable HandlerError
+	ErrRequestedRangeNotSatisfiable = &HandlerError{Code: http.StatusRequestedRangeNotSatisfiable}
+	// Constant http.StatusTooManyRequests HandlerError
+	ErrTooManyRequests = &HandlerError{Code: http.StatusTooManyRequests}
+	// Constant http.StatusServiceUnavailable HandlerError
+	ErrServiceUnavailable = &HandlerError{Code: http.StatusServiceUnavailable}
 )
 
 type BadRequestError struct {
@@ -111,3 +134,524 @@ func NotAcceptable(errs ...error) *NotAcceptableError {
 		ErrorStack: errors.NewErrorStack(errs...),
 	}
 }
+
+type UnauthorizedError struct {
+	errors.ErrorStack
+
+	Header http.Header
+}
+
+func (err *UnauthorizedError) AsError() error {
+	return err
+}
+
+func (err *UnauthorizedError) Status() int {
+	return http.StatusUnauthorized
+}
+
+func (err *UnauthorizedError) Headers() http.Header {
+	if err.Header == nil {
+		err.Header = make(map[string][]string)
+	}
+	return err.Header
+}
+
+func (err *UnauthorizedError) WithHeaders(hdr http.Header) *UnauthorizedError {
+	tools.CopyHeaders(err.Headers(), hdr)
+	return err
+}
+
+func (err *UnauthorizedError) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	serveHTTP(err, w, r)
+}
+
+func (err *UnauthorizedError) TryServeHTTP(w http.ResponseWriter, r *http.Request) error {
+	return tryServeHTTP(err, w, r)
+}
+
+func Unauthorized(errs ...error) *UnauthorizedError {
+	return &UnauthorizedError{
+		ErrorStack: errors.NewErrorStack(errs...),
+	}
+}
+
+// WithChallenge adds a WWW-Authenticate challenge,
+// params are given as key, value pairs
+func (err *UnauthorizedError) WithChallenge(scheme string, params ...string) *UnauthorizedError {
+	err.Headers().Add("WWW-Authenticate", challenge(scheme, params...))
+	return err
+}
+
+type ForbiddenError struct {
+	errors.ErrorStack
+
+	Header http.Header
+}
+
+func (err *ForbiddenError) AsError() error {
+	return err
+}
+
+func (err *ForbiddenError) Status() int {
+	return http.StatusForbidden
+}
+
+func (err *ForbiddenError) Headers() http.Header {
+	if err.Header == nil {
+		err.Header = make(map[string][]string)
+	}
+	return err.Header
+}
+
+func (err *ForbiddenError) WithHeaders(hdr http.Header) *ForbiddenError {
+	tools.CopyHeaders(err.Headers(), hdr)
+	return err
+}
+
+func (err *ForbiddenError) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	serveHTTP(err, w, r)
+}
+
+func (err *ForbiddenError) TryServeHTTP(w http.ResponseWriter, r *http.Request) error {
+	return tryServeHTTP(err, w, r)
+}
+
+func Forbidden(errs ...error) *ForbiddenError {
+	return &ForbiddenError{
+		ErrorStack: errors.NewErrorStack(errs...),
+	}
+}
+
+type ConflictError struct {
+	errors.ErrorStack
+
+	Header http.Header
+}
+
+func (err *ConflictError) AsError() error {
+	return err
+}
+
+func (err *ConflictError) Status() int {
+	return http.StatusConflict
+}
+
+func (err *ConflictError) Headers() http.Header {
+	if err.Header == nil {
+		err.Header = make(map[string][]string)
+	}
+	return err.Header
+}
+
+func (err *ConflictError) WithHeaders(hdr http.Header) *ConflictError {
+	tools.CopyHeaders(err.Headers(), hdr)
+	return err
+}
+
+func (err *ConflictError) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	serveHTTP(err, w, r)
+}
+
+func (err *ConflictError) TryServeHTTP(w http.ResponseWriter, r *http.Request) error {
+	return tryServeHTTP(err, w, r)
+}
+
+func Conflict(errs ...error) *ConflictError {
+	return &ConflictError{
+		ErrorStack: errors.NewErrorStack(errs...),
+	}
+}
+
+type GoneError struct {
+	errors.ErrorStack
+
+	Header http.Header
+}
+
+func (err *GoneError) AsError() error {
+	return err
+}
+
+func (err *GoneError) Status() int {
+	return http.StatusGone
+}
+
+func (err *GoneError) Headers() http.Header {
+	if err.Header == nil {
+		err.Header = make(map[string][]string)
+	}
+	return err.Header
+}
+
+func (err *GoneError) WithHeaders(hdr http.Header) *GoneError {
+	tools.CopyHeaders(err.Headers(), hdr)
+	return err
+}
+
+func (err *GoneError) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	serveHTTP(err, w, r)
+}
+
+func (err *GoneError) TryServeHTTP(w http.ResponseWriter, r *http.Request) error {
+	return tryServeHTTP(err, w, r)
+}
+
+func Gone(errs ...error) *GoneError {
+	return &GoneError{
+		ErrorStack: errors.NewErrorStack(errs...),
+	}
+}
+
+type PreconditionFailedError struct {
+	errors.ErrorStack
+
+	Header http.Header
+}
+
+func (err *PreconditionFailedError) AsError() error {
+	return err
+}
+
+func (err *PreconditionFailedError) Status() int {
+	return http.StatusPreconditionFailed
+}
+
+func (err *PreconditionFailedError) Headers() http.Header {
+	if err.Header == nil {
+		err.Header = make(map[string][]string)
+	}
+	return err.Header
+}
+
+func (err *PreconditionFailedError) WithHeaders(hdr http.Header) *PreconditionFailedError {
+	tools.CopyHeaders(err.Headers(), hdr)
+	return err
+}
+
+func (err *PreconditionFailedError) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	serveHTTP(err, w, r)
+}
+
+func (err *PreconditionFailedError) TryServeHTTP(w http.ResponseWriter, r *http.Request) error {
+	return tryServeHTTP(err, w, r)
+}
+
+func PreconditionFailed(errs ...error) *PreconditionFailedError {
+	return &PreconditionFailedError{
+		ErrorStack: errors.NewErrorStack(errs...),
+	}
+}
+
+type RequestEntityTooLargeError struct {
+	errors.ErrorStack
+
+	Header http.Header
+}
+
+func (err *RequestEntityTooLargeError) AsError() error {
+	return err
+}
+
+func (err *RequestEntityTooLargeError) Status() int {
+	return http.StatusRequestEntityTooLarge
+}
+
+func (err *RequestEntityTooLargeError) Headers() http.Header {
+	if err.Header == nil {
+		err.Header = make(map[string][]string)
+	}
+	return err.Header
+}
+
+func (err *RequestEntityTooLargeError) WithHeaders(hdr http.Header) *RequestEntityTooLargeError {
+	tools.CopyHeaders(err.Headers(), hdr)
+	return err
+}
+
+func (err *RequestEntityTooLargeError) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	serveHTTP(err, w, r)
+}
+
+func (err *RequestEntityTooLargeError) TryServeHTTP(w http.ResponseWriter, r *http.Request) error {
+	return tryServeHTTP(err, w, r)
+}
+
+func RequestEntityTooLarge(errs ...error) *RequestEntityTooLargeError {
+	return &RequestEntityTooLargeError{
+		ErrorStack: errors.NewErrorStack(errs...),
+	}
+}
+
+// WithRetryAfter tells how long to wait before trying again
+func (err *RequestEntityTooLargeError) WithRetryAfter(d time.Duration) *RequestEntityTooLargeError {
+	err.Headers().Set("Retry-After", retryAfter(d))
+	return err
+}
+
+// WithRetryAt tells when to try again
+func (err *RequestEntityTooLargeError) WithRetryAt(t time.Time) *RequestEntityTooLargeError {
+	err.Headers().Set("Retry-After", t.UTC().Format(http.TimeFormat))
+	return err
+}
+
+type UnsupportedMediaTypeError struct {
+	errors.ErrorStack
+
+	Header http.Header
+}
+
+func (err *UnsupportedMediaTypeError) AsError() error {
+	return err
+}
+
+func (err *UnsupportedMediaTypeError) Status() int {
+	return http.StatusUnsupportedMediaType
+}
+
+func (err *UnsupportedMediaTypeError) Headers() http.Header {
+	if err.Header == nil {
+		err.Header = make(map[string][]string)
+	}
+	return err.Header
+}
+
+func (err *UnsupportedMediaTypeError) WithHeaders(hdr http.Header) *UnsupportedMediaTypeError {
+	tools.CopyHeaders(err.Headers(), hdr)
+	return err
+}
+
+func (err *UnsupportedMediaTypeError) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	serveHTTP(err, w, r)
+}
+
+func (err *UnsupportedMediaTypeError) TryServeHTTP(w http.ResponseWriter, r *http.Request) error {
+	return tryServeHTTP(err, w, r)
+}
+
+func UnsupportedMediaType(errs ...error) *UnsupportedMediaTypeError {
+	return &UnsupportedMediaTypeError{
+		ErrorStack: errors.NewErrorStack(errs...),
+	}
+}
+
+// WithAccept tells the media types accepted by the resource
+func (err *UnsupportedMediaTypeError) WithAccept(mimetypes ...string) *UnsupportedMediaTypeError {
+	err.Headers().Set("Accept", strings.Join(mimetypes, ", "))
+	return err
+}
+
+// WithAcceptPost tells the media types accepted by POST
+func (err *UnsupportedMediaTypeError) WithAcceptPost(mimetypes ...string) *UnsupportedMediaTypeError {
+	err.Headers().Set("Accept-Post", strings.Join(mimetypes, ", "))
+	return err
+}
+
+type RequestedRangeNotSatisfiableError struct {
+	errors.ErrorStack
+
+	Header http.Header
+}
+
+func (err *RequestedRangeNotSatisfiableError) AsError() error {
+	return err
+}
+
+func (err *RequestedRangeNotSatisfiableError) Status() int {
+	return http.StatusRequestedRangeNotSatisfiable
+}
+
+func (err *RequestedRangeNotSatisfiableError) Headers() http.Header {
+	if err.Header == nil {
+		err.Header = make(map[string][]string)
+	}
+	return err.Header
+}
+
+func (err *RequestedRangeNotSatisfiableError) WithHeaders(hdr http.Header) *RequestedRangeNotSatisfiableError {
+	tools.CopyHeaders(err.Headers(), hdr)
+	return err
+}
+
+func (err *RequestedRangeNotSatisfiableError) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	serveHTTP(err, w, r)
+}
+
+func (err *RequestedRangeNotSatisfiableError) TryServeHTTP(w http.ResponseWriter, r *http.Request) error {
+	return tryServeHTTP(err, w, r)
+}
+
+func RequestedRangeNotSatisfiable(errs ...error) *RequestedRangeNotSatisfiableError {
+	return &RequestedRangeNotSatisfiableError{
+		ErrorStack: errors.NewErrorStack(errs...),
+	}
+}
+
+// WithSize tells the current length of the representation
+func (err *RequestedRangeNotSatisfiableError) WithSize(size int64) *RequestedRangeNotSatisfiableError {
+	err.Headers().Set("Content-Range", unsatisfiedRange(size))
+	return err
+}
+
+type TooManyRequestsError struct {
+	errors.ErrorStack
+
+	Header http.Header
+}
+
+func (err *TooManyRequestsError) AsError() error {
+	return err
+}
+
+func (err *TooManyRequestsError) Status() int {
+	return http.StatusTooManyRequests
+}
+
+func (err *TooManyRequestsError) Headers() http.Header {
+	if err.Header == nil {
+		err.Header = make(map[string][]string)
+	}
+	return err.Header
+}
+
+func (err *TooManyRequestsError) WithHeaders(hdr http.Header) *TooManyRequestsError {
+	tools.CopyHeaders(err.Headers(), hdr)
+	return err
+}
+
+func (err *TooManyRequestsError) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	serveHTTP(err, w, r)
+}
+
+func (err *TooManyRequestsError) TryServeHTTP(w http.ResponseWriter, r *http.Request) error {
+	return tryServeHTTP(err, w, r)
+}
+
+func TooManyRequests(errs ...error) *TooManyRequestsError {
+	return &TooManyRequestsError{
+		ErrorStack: errors.NewErrorStack(errs...),
+	}
+}
+
+// WithRetryAfter tells how long to wait before trying again
+func (err *TooManyRequestsError) WithRetryAfter(d time.Duration) *TooManyRequestsError {
+	err.Headers().Set("Retry-After", retryAfter(d))
+	return err
+}
+
+// WithRetryAt tells when to try again
+func (err *TooManyRequestsError) WithRetryAt(t time.Time) *TooManyRequestsError {
+	err.Headers().Set("Retry-After", t.UTC().Format(http.TimeFormat))
+	return err
+}
+
+type ServiceUnavailableError struct {
+	errors.ErrorStack
+
+	Header http.Header
+}
+
+func (err *ServiceUnavailableError) AsError() error {
+	return err
+}
+
+func (err *ServiceUnavailableError) Status() int {
+	return http.StatusServiceUnavailable
+}
+
+func (err *ServiceUnavailableError) Headers() http.Header {
+	if err.Header == nil {
+		err.Header = make(map[string][]string)
+	}
+	return err.Header
+}
+
+func (err *ServiceUnavailableError) WithHeaders(hdr http.Header) *ServiceUnavailableError {
+	tools.CopyHeaders(err.Headers(), hdr)
+	return err
+}
+
+func (err *ServiceUnavailableError) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	serveHTTP(err, w, r)
+}
+
+func (err *ServiceUnavailableError) TryServeHTTP(w http.ResponseWriter, r *http.Request) error {
+	return tryServeHTTP(err, w, r)
+}
+
+func ServiceUnavailable(errs ...error) *ServiceUnavailableError {
+	return &ServiceUnavailableError{
+		ErrorStack: errors.NewErrorStack(errs...),
+	}
+}
+
+// WithRetryAfter tells how long to wait before trying again
+func (err *ServiceUnavailableError) WithRetryAfter(d time.Duration) *ServiceUnavailableError {
+	err.Headers().Set("Retry-After", retryAfter(d))
+	return err
+}
+
+// WithRetryAt tells when to try again
+func (err *ServiceUnavailableError) WithRetryAt(t time.Time) *ServiceUnavailableError {
+	err.Headers().Set("Retry-After", t.UTC().Format(http.TimeFormat))
+	return err
+}
+
+// newErrorStack returns the typed error of a status code, if there is one.
+// Validators are left to HandlerError, as a response carries no
+// validation errors to stack
+func newErrorStack(code int, hdr http.Header, errs ...error) web.Error {
+	switch code {
+	case http.StatusUnauthorized:
+		return &UnauthorizedError{
+			ErrorStack: errors.NewErrorStack(errs...),
+			Header:     hdr,
+		}
+	case http.StatusForbidden:
+		return &ForbiddenError{
+			ErrorStack: errors.NewErrorStack(errs...),
+			Header:     hdr,
+		}
+	case http.StatusConflict:
+		return &ConflictError{
+			ErrorStack: errors.NewErrorStack(errs...),
+			Header:     hdr,
+		}
+	case http.StatusGone:
+		return &GoneError{
+			ErrorStack: errors.NewErrorStack(errs...),
+			Header:     hdr,
+		}
+	case http.StatusPreconditionFailed:
+		return &PreconditionFailedError{
+			ErrorStack: errors.NewErrorStack(errs...),
+			Header:     hdr,
+		}
+	case http.StatusRequestEntityTooLarge:
+		return &RequestEntityTooLargeError{
+			ErrorStack: errors.NewErrorStack(errs...),
+			Header:     hdr,
+		}
+	case http.StatusUnsupportedMediaType:
+		return &UnsupportedMediaTypeError{
+			ErrorStack: errors.NewErrorStack(errs...),
+			Header:     hdr,
+		}
+	case http.StatusRequestedRangeNotSatisfiable:
+		return &RequestedRangeNotSatisfiableError{
+			ErrorStack: errors.NewErrorStack(errs...),
+			Header:     hdr,
+		}
+	case http.StatusTooManyRequests:
+		return &TooManyRequestsError{
+			ErrorStack: errors.NewErrorStack(errs...),
+			Header:     hdr,
+		}
+	case http.StatusServiceUnavailable:
+		return &ServiceUnavailableError{
+			ErrorStack: errors.NewErrorStack(errs...),
+			Header:     hdr,
+		}
+	default:
+		return nil
+	}
+}
@@ -13,6 +13,17 @@ package ${GOPACKAGE:-undefined}
 //go:generate $0${*:+ $*}
 EOT
 
+# validator tells if the error collects validation errors,
+# being Ok while none was stacked
+validator() {
+	case "$1" in
+	BadRequest|NotAcceptable)
+		return 0 ;;
+	*)
+		return 1 ;;
+	esac
+}
+
 gen() {
 	local K="$1"
 	local T="${K}Error"
@@ -25,6 +36,11 @@ type $T struct {
 
 	Header http.Header
 }
+EOT
+
+	if validator "$K"; then
+		# only an error once something was stacked
+		cat <<EOT
 
 func (err *$T) AsError() error {
 	if err.Ok() {
@@ -41,6 +57,22 @@ func (err *$T) Status() int {
 		return http.$S
 	}
 }
+EOT
+	else
+		# the status is the error, stacked errors only explain it
+		cat <<EOT
+
+func (err *$T) AsError() error {
+	return err
+}
+
+func (err *$T) Status() int {
+	return http.$S
+}
+EOT
+	fi
+
+	cat <<EOT
 
 func (err *$T) Headers() http.Header {
 	if err.Header == nil {
@@ -68,16 +100,86 @@ func $K(errs... error) *$T {
 	}
 }
 EOT
+
+	# protocol headers
+	case "$K" in
+	Unauthorized)
+		cat <<EOT
+
+// WithChallenge adds a WWW-Authenticate challenge,
+// params are given as key, value pairs
+func (err *$T) WithChallenge(scheme string, params ...string) *$T {
+	err.Headers().Add("WWW-Authenticate", challenge(scheme, params...))
+	return err
+}
+EOT
+		;;
+	UnsupportedMediaType)
+		cat <<EOT
+
+// WithAccept tells the media types accepted by the resource
+func (err *$T) WithAccept(mimetypes ...string) *$T {
+	err.Headers().Set("Accept", strings.Join(mimetypes, ", "))
+	return err
+}
+
+// WithAcceptPost tells the media types accepted by POST
+func (err *$T) WithAcceptPost(mimetypes ...string) *$T {
+	err.Headers().Set("Accept-Post", strings.Join(mimetypes, ", "))
+	return err
+}
+EOT
+		;;
+	RequestedRangeNotSatisfiable)
+		cat <<EOT
+
+// WithSize tells the current length of the representation
+func (err *$T) WithSize(size int64) *$T {
+	err.Headers().Set("Content-Range", unsatisfiedRange(size))
+	return err
+}
+EOT
+		;;
+	RequestEntityTooLarge|TooManyRequests|ServiceUnavailable)
+		cat <<EOT
+
+// WithRetryAfter tells how long to wait before trying again
+func (err *$T) WithRetryAfter(d time.Duration) *$T {
+	err.Headers().Set("Retry-After", retryAfter(d))
+	return err
+}
+
+// WithRetryAt tells when to try again
+func (err *$T) WithRetryAt(t time.Time) *$T {
+	err.Headers().Set("Retry-After", t.UTC().Format(http.TimeFormat))
+	return err
+}
+EOT
+		;;
+	esac
 }
 
 if [ $# -gt 0 ]; then
 
+	# imports needed by the protocol headers
+	imports=
+	for x; do
+		case "$x" in
+		UnsupportedMediaType)
+			imports="$imports strings" ;;
+		RequestEntityTooLarge|TooManyRequests|ServiceUnavailable)
+			imports="$imports time" ;;
+		esac
+	done
+	imports="$(for x in net/http $imports; do echo "$x"; done | sort -u)"
+
 	cat <<EOT
 
 import (
-	"net/http"
+$(for x in $imports; do printf '\t"%s"\n' "$x"; done)
 
 	"go.sancus.dev/core/errors"
+	"go.sancus.dev/web"
 	"go.sancus.dev/web/tools"
 )
 
@@ -95,6 +197,32 @@ EOT
 		gen "$x"
 	done
 
+	cat <<EOT
+
+// newErrorStack returns the typed error of a status code, if there is one.
+// Validators are left to HandlerError, as a response carries no
+// validation errors to stack
+func newErrorStack(code int, hdr http.Header, errs ...error) web.Error {
+	switch code {
+EOT
+	for x; do
+		validator "$x" && continue
+
+		cat <<EOT
+	case http.Status$x:
+		return &${x}Error{
+			ErrorStack: errors.NewErrorStack(errs...),
+			Header:     hdr,
+		}
+EOT
+	done
+	cat <<EOT
+	default:
+		return nil
+	}
+}
+EOT
+
 	if [ -n "${GOFILE:-}" ]; then
 		mv "$GOFILE~" "$GOFILE"
 	fi
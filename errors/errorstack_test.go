@@ -0,0 +1,140 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.sancus.dev/web"
+)
+
+func TestProtocolHeaders(t *testing.T) {
+	at := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	fail := New("nope")
+
+	for _, tc := range []struct {
+		err    web.Error
+		header string
+		value  string
+	}{
+		{Unauthorized(fail).WithChallenge("Bearer", "realm", `a "b"`), "WWW-Authenticate", `Bearer realm="a \"b\""`},
+		{UnsupportedMediaType(fail).WithAccept("text/plain", "application/json"), "Accept", "text/plain, application/json"},
+		{UnsupportedMediaType(fail).WithAcceptPost("text/turtle"), "Accept-Post", "text/turtle"},
+		{RequestedRangeNotSatisfiable(fail).WithSize(1024), "Content-Range", "bytes */1024"},
+		{TooManyRequests(fail).WithRetryAfter(1500 * time.Millisecond), "Retry-After", "2"},
+		{ServiceUnavailable(fail).WithRetryAt(at), "Retry-After", "Sun, 18 Oct 2026 12:00:00 GMT"},
+	} {
+		rec := httptest.NewRecorder()
+		HandleError(rec, httptest.NewRequest("GET", "/", nil), tc.err)
+
+		if rec.Code != tc.err.Status() {
+			t.Errorf("%T: status %v", tc.err, rec.Code)
+		}
+
+		if s := rec.Header().Get(tc.header); s != tc.value {
+			t.Errorf("%T: %s %q instead of %q", tc.err, tc.header, s, tc.value)
+		}
+	}
+}
+
+func TestNewErrorFromResponse(t *testing.T) {
+	for code, want := range map[int]web.Error{
+		401: &UnauthorizedError{},
+		403: &ForbiddenError{},
+		409: &ConflictError{},
+		410: &GoneError{},
+		412: &PreconditionFailedError{},
+		413: &RequestEntityTooLargeError{},
+		415: &UnsupportedMediaTypeError{},
+		416: &RequestedRangeNotSatisfiableError{},
+		429: &TooManyRequestsError{},
+		503: &ServiceUnavailableError{},
+		400: &HandlerError{},
+		406: &HandlerError{},
+		418: &HandlerError{},
+	} {
+		res := &http.Response{
+			StatusCode: code,
+			Header:     http.Header{"Retry-After": []string{"10"}},
+			Body:       http.NoBody,
+		}
+
+		err, _ := NewErrorFromResponse(res).(web.Error)
+		if err == nil {
+			t.Errorf("%v: not a web.Error", code)
+			continue
+		}
+
+		if got, want := typeName(err), typeName(want); got != want {
+			t.Errorf("%v: %s instead of %s", code, got, want)
+		} else if err.Status() != code {
+			t.Errorf("%v: status %v", code, err.Status())
+		}
+
+		if h, ok := err.(interface{ Headers() http.Header }); !ok || h.Headers().Get("Retry-After") != "10" {
+			t.Errorf("%v: headers lost", code)
+		}
+
+		rec := httptest.NewRecorder()
+		HandleError(rec, httptest.NewRequest("GET", "/", nil), err)
+
+		if rec.Code != code {
+			t.Errorf("%v: served as %v", code, rec.Code)
+		} else if s := rec.Header().Get("Retry-After"); s != "10" {
+			t.Errorf("%v: served Retry-After %q", code, s)
+		}
+	}
+}
+
+func TestStatusOnly(t *testing.T) {
+	for _, tc := range []struct {
+		err  web.Error
+		code int
+	}{
+		{Unauthorized().WithChallenge("Bearer"), 401},
+		{Forbidden(), 403},
+		{Conflict(), 409},
+		{Gone(), 410},
+		{PreconditionFailed(), 412},
+		{RequestEntityTooLarge().WithRetryAfter(time.Minute), 413},
+		{UnsupportedMediaType().WithAccept("text/plain"), 415},
+		{RequestedRangeNotSatisfiable().WithSize(10), 416},
+		{TooManyRequests(), 429},
+		{ServiceUnavailable(), 503},
+	} {
+		if code := tc.err.Status(); code != tc.code {
+			t.Errorf("%T: status %v instead of %v", tc.err, code, tc.code)
+		}
+
+		if e, ok := tc.err.(interface{ AsError() error }); !ok || e.AsError() == nil {
+			t.Errorf("%T: not an error", tc.err)
+		}
+
+		rec := httptest.NewRecorder()
+		HandleError(rec, httptest.NewRequest("GET", "/", nil), tc.err)
+
+		if rec.Code != tc.code {
+			t.Errorf("%T: served as %v", tc.err, rec.Code)
+		}
+	}
+
+	// validators are only errors once something was stacked
+	for _, err := range []interface {
+		web.Error
+		AsError() error
+	}{
+		BadRequest(),
+		NotAcceptable(),
+	} {
+		if err.Status() != http.StatusOK || err.AsError() != nil {
+			t.Errorf("%T: empty validator is an error", err)
+		}
+	}
+}
+
+func typeName(v interface{}) string {
+	return strings.TrimPrefix(strings.TrimPrefix(fmt.Sprintf("%T", v), "*"), "errors.")
+}
@@ -0,0 +1,144 @@
+package errors
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log"
+	"net/http"
+	"sync/atomic"
+)
+
+// Exposure tells how much of an error goes into rendered bodies
+type Exposure int32
+
+const (
+	// Development shows everything, including stack traces. The default
+	Development Exposure = iota
+	// Staging hides stack traces
+	Staging
+	// Production only shows the status, and the errors of client
+	// requests (4xx)
+	Production
+)
+
+var exposure int32
+
+// SetExposure sets the error exposure policy
+func SetExposure(e Exposure) {
+	atomic.StoreInt32(&exposure, int32(e))
+}
+
+// GetExposure returns the error exposure policy
+func GetExposure() Exposure {
+	return Exposure(atomic.LoadInt32(&exposure))
+}
+
+func (e Exposure) String() string {
+	switch e {
+	case Development:
+		return "development"
+	case Staging:
+		return "staging"
+	case Production:
+		return "production"
+	default:
+		return "unknown"
+	}
+}
+
+var reporter atomic.Value
+
+type reporterFunc struct {
+	fn func(*http.Request, *ErrorDescriptor)
+}
+
+// SetReporter sets the function receiving the full ErrorDescriptor of
+// responses that had details redacted, identified by the ID shown to
+// the client. nil disables reporting
+func SetReporter(fn func(*http.Request, *ErrorDescriptor)) {
+	reporter.Store(reporterFunc{fn})
+}
+
+// GetReporter returns the function reporting redacted errors,
+// LogReporter unless set otherwise
+func GetReporter() func(*http.Request, *ErrorDescriptor) {
+	if v, ok := reporter.Load().(reporterFunc); ok {
+		return v.fn
+	}
+	return LogReporter
+}
+
+// LogReporter reports redacted errors using the standard logger
+func LogReporter(r *http.Request, desc *ErrorDescriptor) {
+	log.Printf("%s %s %s: %s", desc.ID, r.Method, r.URL, desc.Error())
+
+	if err := desc.Fatal; err != nil {
+		log.Printf("%s panic: %s", desc.ID, err)
+	}
+
+	for _, err := range desc.Err {
+		log.Printf("%s error: %s", desc.ID, err)
+	}
+
+	for _, frame := range desc.Stack {
+		log.Printf("%s %#+v", desc.ID, frame)
+	}
+}
+
+// Redact returns a copy of the ErrorDescriptor without the details
+// an Exposure doesn't allow, and if anything was removed. Redacted
+// copies get a new ID unless the original has one
+func (desc *ErrorDescriptor) Redact(e Exposure) (*ErrorDescriptor, bool) {
+	out := *desc
+
+	if e >= Staging {
+		out.Stack = nil
+	}
+
+	if e >= Production && desc.Code >= 500 {
+		out.Fatal = nil
+		out.Err = nil
+		out.Type = ""
+		out.Extensions = nil
+	}
+
+	ok := redacted(desc, &out)
+	if ok && out.ID == "" {
+		out.ID = NewID()
+	}
+
+	return &out, ok
+}
+
+func redacted(a, b *ErrorDescriptor) bool {
+	return len(a.Stack) != len(b.Stack) ||
+		(a.Fatal == nil) != (b.Fatal == nil) ||
+		len(a.Err) != len(b.Err) ||
+		len(a.Extensions) != len(b.Extensions)
+}
+
+// exposed redacts the ErrorDescriptor following the current policy,
+// reporting the details left out unless they already were
+func (desc *ErrorDescriptor) exposed(req *http.Request) *ErrorDescriptor {
+	out, redacted := desc.Redact(GetExposure())
+
+	if redacted && desc.ID == "" {
+		if fn := GetReporter(); fn != nil {
+			full := *desc
+			full.ID = out.ID
+			fn(req, &full)
+		}
+	}
+
+	return out
+}
+
+// NewID returns a random correlation id
+func NewID() string {
+	var b [8]byte
+
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b[:])
+}
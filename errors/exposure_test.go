@@ -0,0 +1,68 @@
+package errors
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.sancus.dev/web"
+)
+
+func TestExposure(t *testing.T) {
+	var reported *ErrorDescriptor
+
+	defer func(fn func(*http.Request, *ErrorDescriptor)) {
+		SetExposure(Development)
+		SetReporter(fn)
+	}(GetReporter())
+
+	SetReporter(func(r *http.Request, desc *ErrorDescriptor) {
+		reported = desc
+	})
+
+	serve := func(err web.Error) string {
+		reported = nil
+
+		rec := httptest.NewRecorder()
+		AsDescriptor(err).ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+		return rec.Body.String()
+	}
+
+	secret := &HandlerError{Code: 500, Err: New("/srv/secret.go")}
+	invalid := BadRequest(New("invalid id"))
+
+	SetExposure(Development)
+	if s := serve(secret); !strings.Contains(s, "/srv/secret.go") || reported != nil {
+		t.Errorf("development: %q", s)
+	}
+
+	SetExposure(Production)
+	s := serve(secret)
+	if strings.Contains(s, "/srv/secret.go") {
+		t.Errorf("production: leaked %q", s)
+	} else if reported == nil || len(reported.Err) != 1 {
+		t.Errorf("production: not reported")
+	} else if !strings.Contains(s, reported.ID) {
+		t.Errorf("production: %q doesn't show %q", s, reported.ID)
+	}
+
+	if s := serve(invalid); !strings.Contains(s, "invalid id") || reported != nil {
+		t.Errorf("production: %q", s)
+	}
+}
+
+func TestRedact(t *testing.T) {
+	desc := AsDescriptor(&HandlerError{Code: 500, Err: New("/srv/secret.go")})
+
+	out, ok := desc.Redact(Production)
+	if !ok || out.ID == "" || len(out.Err) != 0 {
+		t.Errorf("production: %v %#v", ok, out)
+	} else if desc.ID != "" || len(desc.Err) != 1 {
+		t.Errorf("original changed: %#v", desc)
+	}
+
+	if out, ok := desc.Redact(Development); ok || out.ID != "" {
+		t.Errorf("development: %v %#v", ok, out)
+	}
+}
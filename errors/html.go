@@ -0,0 +1,146 @@
+package errors
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"io"
+	"log"
+	"strconv"
+	"sync"
+)
+
+// DefaultHTMLTemplate is the page used when no better template
+// has been registered
+var DefaultHTMLTemplate = template.Must(template.New("error").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{ .Message }}</title>
+</head>
+<body>
+<h1>{{ .Message }}</h1>
+{{- if .Location }}
+<p>Redirected to <a href="{{ .Location }}">{{ .Location }}</a></p>
+{{- end }}
+{{- if .Fatal }}
+<p>panic: {{ .Fatal }}</p>
+{{- end }}
+{{- if .Err }}
+<ol>
+{{- range .Err }}
+<li>{{ .Error }}</li>
+{{- end }}
+</ol>
+{{- end }}
+{{- if .ID }}
+<p>Reference: {{ .ID }}</p>
+{{- end }}
+{{- if .Stack }}
+<pre>
+{{- range .Stack }}
+{{ printf "%#+v" . }}
+{{- end }}
+</pre>
+{{- end }}
+</body>
+</html>
+`))
+
+// HTML is the HTMLRenderer used by ErrorDescriptor
+var HTML = NewHTMLRenderer()
+
+// HTMLRenderer renders ErrorDescriptors as HTML pages using
+// the template registered for the exact status code, or for its
+// class (4xx, 5xx), or the default one
+type HTMLRenderer struct {
+	mu        sync.RWMutex
+	templates map[string]*template.Template
+}
+
+func NewHTMLRenderer() *HTMLRenderer {
+	return &HTMLRenderer{
+		templates: make(map[string]*template.Template),
+	}
+}
+
+// Template registers a template for a status code ("404"), a status
+// class ("4xx") or, with an empty key, the default page. The template
+// gets a copy of the ErrorDescriptor, already redacted as
+// the Exposure policy requires
+func (h *HTMLRenderer) Template(key string, t *template.Template) *HTMLRenderer {
+	if !validTemplateKey(key) {
+		panic(New("%q: invalid error template key", key))
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if t == nil {
+		delete(h.templates, key)
+	} else {
+		h.templates[key] = t
+	}
+	return h
+}
+
+// Lookup finds the template for a status code
+func (h *HTMLRenderer) Lookup(code int) *template.Template {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	keys := []string{
+		strconv.Itoa(code),
+		fmt.Sprintf("%dxx", code/100),
+		"",
+	}
+
+	for _, k := range keys {
+		if t, ok := h.templates[k]; ok {
+			return t
+		}
+	}
+	return DefaultHTMLTemplate
+}
+
+// Render writes the HTML page of an ErrorDescriptor, falling back
+// to the default page if the template fails
+func (h *HTMLRenderer) Render(w io.Writer, desc *ErrorDescriptor) error {
+	var buf bytes.Buffer
+
+	data := *desc
+	data.Message = ErrorText(desc.Code)
+
+	t := h.Lookup(desc.Code)
+	if err := t.Execute(&buf, &data); err != nil {
+		if t == DefaultHTMLTemplate {
+			return err
+		}
+
+		log.Printf("%+v: %s", Here(), err)
+
+		buf.Reset()
+		if err := DefaultHTMLTemplate.Execute(&buf, &data); err != nil {
+			return err
+		}
+	}
+
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+func validTemplateKey(key string) bool {
+	switch len(key) {
+	case 0:
+		return true
+	case 3:
+		if key[0] < '1' || key[0] > '5' {
+			return false
+		} else if key[1:] == "xx" {
+			return true
+		} else if n, err := strconv.Atoi(key); err == nil {
+			return n >= 100
+		}
+	}
+	return false
+}
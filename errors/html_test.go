@@ -0,0 +1,59 @@
+package errors
+
+import (
+	"html/template"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHTMLRenderer(t *testing.T) {
+	h := NewHTMLRenderer().
+		Template("404", template.Must(template.New("").Parse("missing"))).
+		Template("4xx", template.Must(template.New("").Parse("client {{ .Code }}"))).
+		Template("5xx", template.Must(template.New("").Parse("{{ .Nope }}")))
+
+	for code, want := range map[int]string{
+		404: "missing",
+		403: "client 403",
+		500: "Internal Server Error (Error 500)", // broken, default
+		302: "Redirected to",
+	} {
+		var b strings.Builder
+
+		desc := &ErrorDescriptor{Code: code, Location: "/x"}
+		if err := h.Render(&b, desc); err != nil {
+			t.Errorf("%v: %s", code, err)
+		} else if !strings.Contains(b.String(), want) {
+			t.Errorf("%v: %q doesn't contain %q", code, b.String(), want)
+		}
+	}
+
+	for _, key := range []string{"4x", "600", "4xy", "abc"} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%q: accepted", key)
+				}
+			}()
+			h.Template(key, DefaultHTMLTemplate)
+		}()
+	}
+}
+
+func TestHTMLNegotiation(t *testing.T) {
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,*/*;q=0.8")
+	rec := httptest.NewRecorder()
+
+	desc := AsDescriptor(BadRequest(New("<b>")))
+	desc.ServeHTTP(rec, req)
+
+	if s := rec.Header().Get("Content-Type"); !strings.HasPrefix(s, "text/html") {
+		t.Errorf("Content-Type %q", s)
+	}
+
+	if s := rec.Body.String(); !strings.Contains(s, "&lt;b&gt;") {
+		t.Errorf("unescaped body %q", s)
+	}
+}
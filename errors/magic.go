@@ -38,6 +38,8 @@ func newWebError(code int, headers http.Header, body []byte, readError error) we
 		return &MethodNotAllowedError{
 			Allowed: strings.Split(allowed, ", "),
 		}
+	} else if err := newErrorStack(code, headers, responseError(code, readError)); err != nil {
+		return err
 	} else {
 		return &HandlerError{
 			Code:   code,
@@ -47,6 +49,15 @@ func newWebError(code int, headers http.Header, body []byte, readError error) we
 	}
 }
 
+// responseError is what typed errors hold when created from a response,
+// as they need at least one
+func responseError(code int, readError error) error {
+	if readError != nil {
+		return readError
+	}
+	return New("%s", ErrorText(code))
+}
+
 func AsWebError(err error) web.Error {
 	var p web.Error
 	var ok bool
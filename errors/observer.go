@@ -0,0 +1,118 @@
+package errors
+
+import (
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.sancus.dev/web"
+	"go.sancus.dev/web/context"
+)
+
+// Observation describes an error handled by HandleError
+type Observation struct {
+	Time    time.Time
+	Request *http.Request
+	Error   web.Error
+	Stack   Stack
+	Pattern string // RoutePattern of the RoutingContext, if any
+}
+
+// Status is the HTTP status of the observed error
+func (o *Observation) Status() int {
+	return o.Error.Status()
+}
+
+// Observer is told about every error handled by HandleError
+type Observer interface {
+	ObserveError(*Observation)
+}
+
+// ObserverFunc is a function usable as Observer
+type ObserverFunc func(*Observation)
+
+func (f ObserverFunc) ObserveError(o *Observation) {
+	f(o)
+}
+
+var observers struct {
+	sync.RWMutex
+	list []*observer
+}
+
+type observer struct {
+	Observer
+}
+
+// AddObserver registers an Observer, returning the function
+// to remove it
+func AddObserver(o Observer) func() {
+	if o == nil {
+		panic(New("nil observer"))
+	}
+
+	p := &observer{o}
+
+	observers.Lock()
+	defer observers.Unlock()
+
+	// copy on write, so Observe() doesn't hold the lock
+	// while calling them
+	l := make([]*observer, len(observers.list), len(observers.list)+1)
+	copy(l, observers.list)
+	observers.list = append(l, p)
+
+	return func() {
+		observers.Lock()
+		defer observers.Unlock()
+
+		var l []*observer
+		for _, v := range observers.list {
+			if v != p {
+				l = append(l, v)
+			}
+		}
+		observers.list = l
+	}
+}
+
+// Observe tells the registered Observers about an error
+func Observe(r *http.Request, err error) {
+	observers.RLock()
+	l := observers.list
+	observers.RUnlock()
+
+	if len(l) == 0 || err == nil {
+		return
+	}
+
+	o := &Observation{
+		Time:    time.Now(),
+		Request: r,
+		Error:   AsWebError(err),
+		Stack:   StackTrace(err),
+	}
+
+	if r != nil {
+		if rctx := context.RouteContext(r.Context()); rctx != nil {
+			o.Pattern = rctx.RoutePattern
+		}
+	}
+
+	for _, p := range l {
+		p.observe(o)
+	}
+}
+
+// observe calls the Observer, making sure it can't take
+// the request down with it
+func (p *observer) observe(o *Observation) {
+	defer func() {
+		if v := recover(); v != nil {
+			log.Printf("%+v: observer %T panicked: %v", Here(), p.Observer, v)
+		}
+	}()
+
+	p.ObserveError(o)
+}
@@ -0,0 +1,105 @@
+package errors
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.sancus.dev/web/context"
+)
+
+func TestObservers(t *testing.T) {
+	var logged bytes.Buffer
+
+	ring := NewRingObserver(2)
+	all := NewRingObserver(3)
+	all.MinStatus = 400
+	logger := &LogObserver{Logger: log.New(&logged, "", 0)}
+
+	for _, o := range []Observer{
+		ObserverFunc(func(*Observation) { panic("broken observer") }),
+		ring,
+		all,
+		logger,
+	} {
+		defer AddObserver(o)()
+	}
+
+	rctx := context.NewRouteContext("/", "/items/3")
+	rctx.RoutePattern = "/items/{id}"
+
+	for _, err := range []error{
+		New("first"),
+		ErrNotFound,
+		&HandlerError{Code: 503, Err: New("down")},
+	} {
+		req := httptest.NewRequest("GET", "/items/3", nil)
+		req = req.WithContext(context.WithRouteContext(req.Context(), rctx))
+
+		HandleError(httptest.NewRecorder(), req, err)
+	}
+
+	entries := ring.Entries()
+	if len(entries) != 2 {
+		t.Fatalf("%v entries", len(entries))
+	} else if e := entries[0]; e.Status != 503 || e.Error != "down" || e.Pattern != "/items/{id}" {
+		t.Errorf("unexpected %+v", e)
+	} else if e := entries[1]; e.Status != 500 || e.Error != "first" {
+		t.Errorf("unexpected %+v", e)
+	}
+
+	if entries := all.Entries(); len(entries) != 3 || entries[1].Status != 404 {
+		t.Errorf("unexpected %+v", entries)
+	}
+
+	s := logged.String()
+	if !strings.Contains(s, `error="first"`) || !strings.Contains(s, "status=503") {
+		t.Errorf("unexpected log %q", s)
+	} else if strings.Contains(s, "status=404") {
+		t.Errorf("404 logged: %q", s)
+	}
+
+	rec := httptest.NewRecorder()
+	ring.ServeHTTP(rec, httptest.NewRequest("GET", "/debug/errors", nil))
+	if !strings.Contains(rec.Body.String(), "/items/3 (/items/{id})") {
+		t.Errorf("unexpected page %q", rec.Body.String())
+	}
+}
+
+// unrenderable can't be encoded as problem+json
+type unrenderable struct{}
+
+func (unrenderable) Error() string { return "unrenderable" }
+func (unrenderable) Status() int   { return 500 }
+
+func (e unrenderable) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	serveHTTP(e, w, r)
+}
+
+func (unrenderable) Problem() (string, map[string]interface{}) {
+	return "", map[string]interface{}{
+		"ch": make(chan int),
+	}
+}
+
+func TestObserveRenderFailure(t *testing.T) {
+	var count int
+
+	defer AddObserver(ObserverFunc(func(*Observation) { count++ }))()
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Accept", ProblemMimeType)
+	rec := httptest.NewRecorder()
+
+	HandleError(rec, req, unrenderable{})
+
+	if count != 1 {
+		t.Errorf("observed %v times", count)
+	}
+	if rec.Code != 500 || !strings.Contains(rec.Body.String(), ErrorText(500)) {
+		t.Errorf("unexpected %v %q", rec.Code, rec.Body.String())
+	}
+}
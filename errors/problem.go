@@ -0,0 +1,102 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ProblemMimeType is the media type of RFC 9457 problem details
+const ProblemMimeType = "application/problem+json"
+
+// Problem is a RFC 9457 problem details object
+type Problem struct {
+	Type       string                 `json:"type,omitempty"`
+	Title      string                 `json:"title"`
+	Status     int                    `json:"status"`
+	Detail     string                 `json:"detail,omitempty"`
+	Instance   string                 `json:"instance,omitempty"`
+	Extensions map[string]interface{} `json:"-"`
+}
+
+// Problemer is implemented by errors able to tell their problem type
+// URI and extension members for problem+json responses
+type Problemer interface {
+	Problem() (typ string, extensions map[string]interface{})
+}
+
+// MarshalJSON renders the extension members alongside the standard ones,
+// which can't be overridden
+func (p Problem) MarshalJSON() ([]byte, error) {
+	type problem Problem
+
+	b, err := json.Marshal(problem(p))
+	if err != nil || len(p.Extensions) == 0 {
+		return b, err
+	}
+
+	m := make(map[string]interface{}, len(p.Extensions)+5)
+	for k, v := range p.Extensions {
+		m[k] = v
+	}
+
+	var std map[string]interface{}
+	if err := json.Unmarshal(b, &std); err != nil {
+		return nil, err
+	}
+	for k, v := range std {
+		m[k] = v
+	}
+
+	return json.Marshal(m)
+}
+
+// Problem returns the problem details of the descriptor
+func (desc *ErrorDescriptor) Problem(instance string) *Problem {
+	p := &Problem{
+		Type:       desc.Type,
+		Title:      ErrorText(desc.Code),
+		Status:     desc.Code,
+		Instance:   instance,
+		Extensions: desc.Extensions,
+	}
+
+	if desc.ID != "" {
+		ext := make(map[string]interface{}, len(desc.Extensions)+1)
+		for k, v := range desc.Extensions {
+			ext[k] = v
+		}
+		ext["id"] = desc.ID
+		p.Extensions = ext
+	}
+
+	if err := desc.Fatal; err != nil {
+		p.Detail = fmt.Sprintf("panic: %s", err)
+	} else if len(desc.Err) == 1 {
+		p.Detail = desc.Err[0].Error()
+	}
+
+	return p
+}
+
+func (desc *ErrorDescriptor) renderProblem(instance string) ([]byte, error) {
+	return json.MarshalIndent(desc.Problem(instance), "", "  ")
+}
+
+// Problem lists the stacked errors as the "errors" extension member
+func (err *BadRequestError) Problem() (string, map[string]interface{}) {
+	var errs []map[string]string
+
+	for _, e := range err.Errors() {
+		errs = append(errs, map[string]string{
+			"detail": e.Error(),
+		})
+	}
+
+	if len(errs) == 0 {
+		return "", nil
+	}
+
+	return "", map[string]interface{}{
+		"errors": errs,
+	}
+}
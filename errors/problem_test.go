@@ -0,0 +1,48 @@
+package errors
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestProblem(t *testing.T) {
+	err := BadRequest(New("foo"), New("bar"))
+
+	req := httptest.NewRequest("POST", "/items", nil)
+	req.Header.Set("Accept", ProblemMimeType)
+	rec := httptest.NewRecorder()
+
+	AsDescriptor(err).ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status %v", rec.Code)
+	}
+
+	if s := rec.Header().Get("Content-Type"); !strings.HasPrefix(s, ProblemMimeType) {
+		t.Errorf("Content-Type %q", s)
+	}
+
+	var p struct {
+		Title    string `json:"title"`
+		Status   int    `json:"status"`
+		Instance string `json:"instance"`
+		Errors   []struct {
+			Detail string `json:"detail"`
+		} `json:"errors"`
+	}
+
+	if err := json.Unmarshal(rec.Body.Bytes(), &p); err != nil {
+		t.Fatal(err)
+	}
+
+	if p.Status != 400 || p.Instance != "/items" || p.Title == "" {
+		t.Errorf("unexpected %+v", p)
+	}
+
+	if len(p.Errors) != 2 || p.Errors[1].Detail != "bar" {
+		t.Errorf("unexpected errors %+v", p.Errors)
+	}
+}
@@ -0,0 +1,46 @@
+package errors
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// challenge renders a WWW-Authenticate challenge, RFC 9110 section 11.6.1
+func challenge(scheme string, params ...string) string {
+	if len(params)%2 != 0 {
+		panic(New("%q: odd number of challenge parameters", scheme))
+	}
+
+	var s []string
+	for i := 0; i < len(params); i += 2 {
+		s = append(s, fmt.Sprintf("%s=%s", params[i], quote(params[i+1])))
+	}
+
+	if len(s) == 0 {
+		return scheme
+	}
+	return scheme + " " + strings.Join(s, ", ")
+}
+
+// quote renders a quoted-string, RFC 9110 section 5.6.4
+func quote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
+
+// unsatisfiedRange renders the Content-Range of a 416
+func unsatisfiedRange(size int64) string {
+	return fmt.Sprintf("bytes */%d", size)
+}
+
+// retryAfter renders a delay in seconds, rounding up
+func retryAfter(d time.Duration) string {
+	secs := int64((d + time.Second - 1) / time.Second)
+	if secs < 0 {
+		secs = 0
+	}
+	return strconv.FormatInt(secs, 10)
+}
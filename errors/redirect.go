@@ -65,6 +65,11 @@ func newRedirect(code int, location string, args ...interface{}) *RedirectError
 	}
 }
 
+// NewRedirect creates a redirect with a given status code
+func NewRedirect(code int, location string, args ...interface{}) *RedirectError {
+	return newRedirect(code, location, args...)
+}
+
 // 301
 func NewMovedPermanently(location string, args ...interface{}) *RedirectError {
 	return newRedirect(http.StatusMovedPermanently, location, args...)
@@ -115,6 +120,16 @@ func AsRedirect(err error) (*RedirectError, bool) {
 					p := newRedirect(code, loc)
 					return p, true
 				}
+			} else if e, ok := err.(interface {
+				Headers() http.Header
+			}); ok {
+				// Redirect with `Headers() http.Header` interface,
+				// like a HandlerError passed by value
+				if loc := e.Headers().Get("Location"); loc != "" {
+					// Redirect
+					p := newRedirect(code, loc)
+					return p, true
+				}
 			} else if e, ok := err.(interface {
 				Header() http.Header
 			}); ok {
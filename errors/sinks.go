@@ -0,0 +1,176 @@
+package errors
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LogObserver logs observed errors as logfmt key=value pairs
+type LogObserver struct {
+	Logger    *log.Logger // standard logger if nil
+	MinStatus int         // 500 if not set
+	Stack     bool        // log the stack trace too
+}
+
+func (l *LogObserver) ObserveError(o *Observation) {
+	code := o.Status()
+	if code < minStatus(l.MinStatus) {
+		return
+	}
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "status=%d", code)
+	if r := o.Request; r != nil {
+		fmt.Fprintf(&b, " method=%s path=%s", r.Method, strconv.Quote(r.URL.Path))
+	}
+	if o.Pattern != "" {
+		fmt.Fprintf(&b, " pattern=%s", strconv.Quote(o.Pattern))
+	}
+	fmt.Fprintf(&b, " error=%s", strconv.Quote(describe(o.Error)))
+
+	if l.Stack && len(o.Stack) > 0 {
+		frames := make([]string, len(o.Stack))
+		for i, frame := range o.Stack {
+			frames[i] = fmt.Sprintf("%+v", frame)
+		}
+		fmt.Fprintf(&b, " stack=%s", strconv.Quote(strings.Join(frames, ";")))
+	}
+
+	if l.Logger != nil {
+		l.Logger.Print(b.String())
+	} else {
+		log.Print(b.String())
+	}
+}
+
+// minStatus applies the default of the MinStatus fields
+func minStatus(min int) int {
+	if min == 0 {
+		return http.StatusInternalServerError
+	}
+	return min
+}
+
+// describe tells the underlying error instead of only the status text
+func describe(err error) string {
+	if p, ok := err.(interface {
+		Recovered() error
+	}); ok {
+		if e := p.Recovered(); e != nil {
+			return fmt.Sprintf("panic: %s", e)
+		}
+	}
+
+	if e := Unwrap(err); e != nil {
+		return e.Error()
+	}
+	return err.Error()
+}
+
+// RingEntry is an Observation as kept by a RingObserver
+type RingEntry struct {
+	Time    time.Time
+	Status  int
+	Method  string
+	URL     string
+	Pattern string
+	Error   string
+	Stack   Stack
+}
+
+// RingObserver keeps the last observed errors in memory, server
+// errors unless MinStatus says otherwise, and serves them as a
+// debug page
+type RingObserver struct {
+	MinStatus int // 500 if not set
+
+	mu      sync.Mutex
+	entries []RingEntry
+	next    int
+	full    bool
+}
+
+// NewRingObserver creates a RingObserver keeping size entries
+func NewRingObserver(size int) *RingObserver {
+	if size < 1 {
+		panic(New("%v: invalid ring size", size))
+	}
+
+	return &RingObserver{
+		entries: make([]RingEntry, size),
+	}
+}
+
+func (ring *RingObserver) ObserveError(o *Observation) {
+	code := o.Status()
+	if code < minStatus(ring.MinStatus) {
+		return
+	}
+
+	e := RingEntry{
+		Time:    o.Time,
+		Status:  code,
+		Pattern: o.Pattern,
+		Error:   describe(o.Error),
+		Stack:   o.Stack,
+	}
+
+	// don't keep the request alive
+	if r := o.Request; r != nil {
+		e.Method = r.Method
+		e.URL = r.URL.String()
+	}
+
+	ring.mu.Lock()
+	defer ring.mu.Unlock()
+
+	ring.entries[ring.next] = e
+	ring.next++
+	if ring.next == len(ring.entries) {
+		ring.next = 0
+		ring.full = true
+	}
+}
+
+// Entries returns the kept entries, newest first
+func (ring *RingObserver) Entries() []RingEntry {
+	ring.mu.Lock()
+	defer ring.mu.Unlock()
+
+	n := ring.next
+	if ring.full {
+		n = len(ring.entries)
+	}
+
+	out := make([]RingEntry, 0, n)
+	for i := 1; i <= n; i++ {
+		j := (ring.next - i + len(ring.entries)) % len(ring.entries)
+		out = append(out, ring.entries[j])
+	}
+	return out
+}
+
+// ServeHTTP lists the kept entries as text
+func (ring *RingObserver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "no-store")
+
+	for _, e := range ring.Entries() {
+		fmt.Fprintf(w, "%s %d %s %s", e.Time.Format(time.RFC3339), e.Status, e.Method, e.URL)
+		if e.Pattern != "" {
+			fmt.Fprintf(w, " (%s)", e.Pattern)
+		}
+		fmt.Fprintf(w, "\n\t%s\n", e.Error)
+
+		for _, frame := range e.Stack {
+			fmt.Fprintf(w, "\t%#+v\n", frame)
+		}
+	}
+}
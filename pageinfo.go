@@ -20,7 +20,7 @@ const (
 )
 
 func (f ChangeFrequency) String() string {
-	s := []string{"", "never", "always", "hourly", "weekly", "monthly", "yearly"}
+	s := []string{"", "never", "always", "hourly", "daily", "weekly", "monthly", "yearly"}
 	n := int(f)
 
 	if f > ChangeFrequencyUnknown &&
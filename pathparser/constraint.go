@@ -0,0 +1,296 @@
+package pathparser
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Constraint validates and converts the values of typed captures
+// like {id<int>}
+type Constraint interface {
+	// Expr returns the regular expression equivalent to the Constraint
+	Expr() string
+	// Match tells if a segment satisfies the Constraint
+	Match(string) bool
+	// Convert turns a matching segment into its typed value
+	Convert(string) (interface{}, error)
+}
+
+// ConstraintFormatter is a Constraint that knows how to render
+// its typed values back into a segment
+type ConstraintFormatter interface {
+	Format(interface{}) (string, error)
+}
+
+type constraint struct {
+	expr    string
+	match   func(string) bool
+	convert func(string) (interface{}, error)
+	format  func(interface{}) (string, error)
+}
+
+func (c *constraint) Expr() string {
+	return c.expr
+}
+
+func (c *constraint) Match(s string) bool {
+	return c.match(s)
+}
+
+func (c *constraint) Convert(s string) (interface{}, error) {
+	if c.convert == nil {
+		return s, nil
+	}
+	return c.convert(s)
+}
+
+func (c *constraint) Format(v interface{}) (string, error) {
+	if c.format == nil {
+		return fmt.Sprint(v), nil
+	}
+	return c.format(v)
+}
+
+// NewConstraint creates a Constraint from a regular expression
+// and an optional conversion function
+func NewConstraint(expr string, convert func(string) (interface{}, error)) (Constraint, error) {
+	re, err := regexp.Compile("^(?:" + expr + ")$")
+	if err != nil {
+		return nil, err
+	}
+
+	c := &constraint{
+		expr:    expr,
+		match:   re.MatchString,
+		convert: convert,
+	}
+
+	if convert != nil {
+		// a value is only acceptable if it can be converted
+		c.match = func(s string) bool {
+			if re.MatchString(s) {
+				_, err := convert(s)
+				return err == nil
+			}
+			return false
+		}
+	}
+
+	return c, nil
+}
+
+var constraints = struct {
+	sync.RWMutex
+	m map[string]Constraint
+}{
+	m: make(map[string]Constraint),
+}
+
+// RegisterConstraint makes a Constraint available as {name<constraint>}.
+// Constraints have to be registered before the patterns using them
+// are parsed, and names can't be reused.
+func RegisterConstraint(name string, c Constraint) error {
+	if !validConstraintName(name) {
+		return fmt.Errorf("%q: invalid constraint name", name)
+	} else if c == nil {
+		return fmt.Errorf("%q: constraint missing", name)
+	}
+
+	constraints.Lock()
+	defer constraints.Unlock()
+
+	if _, ok := constraints.m[name]; ok {
+		return fmt.Errorf("%q: constraint already registered", name)
+	}
+
+	constraints.m[name] = c
+	return nil
+}
+
+// GetConstraint returns a registered Constraint by name
+func GetConstraint(name string) (Constraint, bool) {
+	constraints.RLock()
+	defer constraints.RUnlock()
+
+	c, ok := constraints.m[name]
+	return c, ok
+}
+
+func validConstraintName(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_', r == '-':
+			// good
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func isHex(s string) bool {
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c >= '0' && c <= '9', c >= 'a' && c <= 'f', c >= 'A' && c <= 'F':
+			// good
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func matchInt(s string) bool {
+	if isDigits(strings.TrimPrefix(s, "-")) {
+		// the whole string, so the minimum fits
+		_, err := strconv.ParseInt(s, 10, strconv.IntSize)
+		return err == nil
+	}
+	return false
+}
+
+func convertInt(s string) (interface{}, error) {
+	return strconv.Atoi(s)
+}
+
+func matchUint(s string) bool {
+	if isDigits(s) {
+		_, err := strconv.ParseUint(s, 10, strconv.IntSize)
+		return err == nil
+	}
+	return false
+}
+
+func convertUint(s string) (interface{}, error) {
+	v, err := strconv.ParseUint(s, 10, strconv.IntSize)
+	return uint(v), err
+}
+
+func matchSlug(s string) bool {
+	if s == "" || s[0] == '-' || s[len(s)-1] == '-' {
+		return false
+	}
+
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c >= 'a' && c <= 'z', c >= '0' && c <= '9':
+			// good
+		case c == '-' && s[i-1] != '-':
+			// no consecutive dashes
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func matchUUID(s string) bool {
+	// 8-4-4-4-12
+	if len(s) != 36 {
+		return false
+	}
+
+	for i, n := range []int{8, 13, 18, 23} {
+		if s[n] != '-' {
+			return false
+		} else if i == 0 && !isHex(s[:n]) {
+			return false
+		} else if i > 0 && !isHex(s[n-4:n]) {
+			return false
+		}
+	}
+
+	return isHex(s[24:])
+}
+
+func convertUUID(s string) (interface{}, error) {
+	return strings.ToLower(s), nil
+}
+
+const dateLayout = "2006-01-02"
+
+func matchDate(s string) bool {
+	if len(s) == len(dateLayout) && s[4] == '-' && s[7] == '-' &&
+		isDigits(s[:4]) && isDigits(s[5:7]) && isDigits(s[8:]) {
+		_, err := time.Parse(dateLayout, s)
+		return err == nil
+	}
+	return false
+}
+
+func convertDate(s string) (interface{}, error) {
+	return time.Parse(dateLayout, s)
+}
+
+func formatDate(v interface{}) (string, error) {
+	switch t := v.(type) {
+	case time.Time:
+		return t.Format(dateLayout), nil
+	case interface {
+		Time() time.Time
+	}:
+		return t.Time().Format(dateLayout), nil
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
+
+func init() {
+	for name, c := range map[string]*constraint{
+		// {id<int>} as int
+		"int": {
+			expr:    `-?[0-9]+`,
+			match:   matchInt,
+			convert: convertInt,
+		},
+		// {id<uint>} as uint
+		"uint": {
+			expr:    `[0-9]+`,
+			match:   matchUint,
+			convert: convertUint,
+		},
+		// {slug<slug>} as string
+		"slug": {
+			expr:  `[a-z0-9]+(?:-[a-z0-9]+)*`,
+			match: matchSlug,
+		},
+		// {id<uuid>} as lowercase string
+		"uuid": {
+			expr:    `[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`,
+			match:   matchUUID,
+			convert: convertUUID,
+		},
+		// {d<date>} as time.Time
+		"date": {
+			expr:    `[0-9]{4}-[0-9]{2}-[0-9]{2}`,
+			match:   matchDate,
+			convert: convertDate,
+			format:  formatDate,
+		},
+	} {
+		if err := RegisterConstraint(name, c); err != nil {
+			panic(err)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package pathparser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuiltinConstraints(t *testing.T) {
+	minInt := strconv.Itoa(-1 << (strconv.IntSize - 1))
+
+	for _, tc := range []struct {
+		name  string
+		value string
+		ok    bool
+		want  interface{}
+	}{
+		{"int", "42", true, 42},
+		{"int", "-7", true, -7},
+		{"int", minInt, true, -1 << (strconv.IntSize - 1)},
+		{"int", "99999999999999999999", false, nil},
+		{"int", "-", false, nil},
+		{"int", "4x", false, nil},
+		{"uint", "7", true, uint(7)},
+		{"uint", "-7", false, nil},
+		{"slug", "hello-world", true, "hello-world"},
+		{"slug", "hello--world", false, nil},
+		{"slug", "-hello", false, nil},
+		{"uuid", "6BA7B810-9DAD-11D1-80B4-00C04FD430C8", true, "6ba7b810-9dad-11d1-80b4-00c04fd430c8"},
+		{"uuid", "6ba7b810-9dad-11d1-80b4-00c04fd430cX", false, nil},
+		{"date", "2026-02-28", true, time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC)},
+		{"date", "2026-02-30", false, nil},
+	} {
+		c, ok := GetConstraint(tc.name)
+		if !ok {
+			t.Fatalf("%q: not registered", tc.name)
+		}
+
+		if ok := c.Match(tc.value); ok != tc.ok {
+			t.Errorf("%s %q: match %v", tc.name, tc.value, ok)
+			continue
+		} else if !ok {
+			continue
+		}
+
+		v, err := c.Convert(tc.value)
+		if err != nil {
+			t.Errorf("%s %q: %s", tc.name, tc.value, err)
+		} else if v != tc.want {
+			t.Errorf("%s %q: %#v instead of %#v", tc.name, tc.value, v, tc.want)
+		}
+	}
+}
+
+func TestRegisterConstraint(t *testing.T) {
+	upper := func(s string) (interface{}, error) {
+		if s == "NOPE" {
+			return nil, fmt.Errorf("%q: refused", s)
+		}
+		return strings.ToUpper(s), nil
+	}
+
+	c, err := NewConstraint(`[a-z]+`, upper)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := RegisterConstraint("test-alpha", c); err != nil {
+		t.Fatal(err)
+	} else if err := RegisterConstraint("test-alpha", c); err == nil {
+		t.Error("name reused")
+	} else if err := RegisterConstraint("bad name", c); err == nil {
+		t.Error("invalid name accepted")
+	} else if err := RegisterConstraint("test-nil", nil); err == nil {
+		t.Error("nil constraint accepted")
+	}
+
+	if _, err := NewConstraint(`[a-z`, nil); err == nil {
+		t.Error("invalid expression accepted")
+	}
+
+	for s, ok := range map[string]bool{
+		"abc":  true,
+		"ab1":  false,
+		"nope": true,
+	} {
+		if c.Match(s) != ok {
+			t.Errorf("%q: match %v", s, !ok)
+		}
+	}
+
+	// conversion errors make the value unacceptable
+	c2, _ := NewConstraint(`[A-Z]+`, upper)
+	if c2.Match("NOPE") {
+		t.Error("unconvertible value matched")
+	} else if _, err := c2.Convert("NOPE"); err == nil {
+		t.Error("conversion error lost")
+	}
+
+	// patterns can use it once registered
+	re, _ := MustCompile("/{name<test-alpha>}")
+	if !re.MatchString("/abc") || re.MatchString("/ABC") {
+		t.Errorf("%q: unexpected", re)
+	}
+}
+
+func TestTemplateConstraint(t *testing.T) {
+	peg := &Peg{Buffer: "/posts/{d<date>}/{id<int>}"}
+	peg.Init()
+	if err := peg.Parse(); err != nil {
+		t.Fatal(err)
+	}
+	peg.Execute()
+
+	tmpl := peg.Template()
+
+	s, err := tmpl.Expand(map[string]interface{}{
+		"d":  time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC),
+		"id": 3,
+	})
+	if err != nil {
+		t.Fatal(err)
+	} else if s != "/posts/2026-10-18/3" {
+		t.Errorf("%q", s)
+	}
+
+	if _, err := tmpl.Expand(map[string]interface{}{"d": "today", "id": 3}); err == nil {
+		t.Error("invalid date accepted")
+	}
+}
+
+func TestSingleOptionCapture(t *testing.T) {
+	// a single option is a literal, even when a constraint has its name
+	for _, pattern := range []string{"/{kind:date}", "/{kind:late-option}"} {
+		p, err := Parse(pattern)
+		if err != nil {
+			t.Fatalf("%q: %s", pattern, err)
+		}
+
+		option := strings.TrimSuffix(strings.TrimPrefix(pattern, "/{kind:"), "}")
+		if v := p.Template()[1]; v.Constraint != "" || len(v.Options) != 1 || v.Options[0] != option {
+			t.Errorf("%q: %#v", pattern, v)
+		}
+
+		re, _ := MustCompile(pattern)
+		if !re.MatchString("/"+option) || re.MatchString("/2026-10-18") {
+			t.Errorf("%q: %q not literal", pattern, re)
+		}
+	}
+
+	// registering the name later doesn't change it
+	c, _ := NewConstraint(`[0-9]+`, nil)
+	if err := RegisterConstraint("late-option", c); err != nil {
+		t.Fatal(err)
+	}
+
+	p, _ := Parse("/{kind:late-option}")
+	if v := p.Template()[1]; v.Constraint != "" {
+		t.Errorf("late constraint taken: %#v", v)
+	}
+}
+
+func TestUnknownConstraint(t *testing.T) {
+	if _, err := Parse("/{id<nope>}"); err == nil {
+		t.Error("unregistered constraint accepted")
+	}
+
+	p, err := Parse("/{id<int>}")
+	if err != nil {
+		t.Fatal(err)
+	} else if v := p.Template()[1]; v.Constraint != "int" || len(v.Options) != 0 {
+		t.Errorf("%#v", v)
+	}
+}
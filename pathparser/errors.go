@@ -0,0 +1,33 @@
+package pathparser
+
+import (
+	"fmt"
+)
+
+// SyntaxError describes a malformed path pattern
+type SyntaxError struct {
+	Pattern string
+	Column  int // of the offending character, starting at 1
+	Err     error
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%q: syntax error at column %v", e.Pattern, e.Column)
+}
+
+func (e *SyntaxError) Unwrap() error {
+	return e.Err
+}
+
+// SyntaxError turns an error from Parse() into a SyntaxError
+func (p *Peg) SyntaxError(err error) error {
+	if e, ok := err.(*parseError); ok {
+		return &SyntaxError{
+			Pattern: p.Buffer,
+			// the first character after the longest valid prefix
+			Column: int(e.max.end) + 1,
+			Err:    err,
+		}
+	}
+	return err
+}
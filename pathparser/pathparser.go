@@ -15,6 +15,8 @@ const (
 	nodeLiteral nodeType = iota
 	nodeCaptureOption
 	nodeCaptureIdentifier
+	nodeCaptureRest
+	nodeCaptureConstraint
 	nodeSpecial
 )
 
@@ -25,14 +27,28 @@ type Node struct {
 
 type Path struct {
 	nodes []Node
+	parts [][]Part
+	rest  bool
+	err   error
 }
 
 func (p *Path) addNode(t nodeType, text string) {
 	p.nodes = append(p.nodes, Node{t, text})
 }
 
+func (p *Path) addLiteral(text string) {
+	p.addNode(nodeLiteral, regexp.QuoteMeta(text))
+	p.addPart(Part{Type: PartLiteral, Value: text})
+}
+
+func (p *Path) addStar() {
+	p.addNode(nodeSpecial, "[^/]+")
+	p.addPart(Part{Type: PartStar})
+}
+
 func (p *Path) beginOptional() {
 	p.addNode(nodeSpecial, "[")
+	p.pushParts()
 }
 
 func (p *Path) beginCapture() {
@@ -43,17 +59,23 @@ func (p *Path) endOptional() {
 	var np *Node
 	var s []string
 	var v string
+	t := nodeLiteral
 	l := len(p.nodes)
 	i := l - 1
 
 	for {
 		np = &p.nodes[i]
-		if np.t == nodeLiteral {
-			s = append(s, np.s)
-			i--
-		} else {
+		if np.t == nodeSpecial && np.s == "[" {
 			break
+		} else if np.t != nodeLiteral {
+			// captures within
+			t = nodeSpecial
 		}
+		i--
+	}
+
+	for _, np := range p.nodes[i+1:] {
+		s = append(s, np.s)
 	}
 
 	v = fmt.Sprintf("(%s)?", strings.Join(s, ""))
@@ -62,7 +84,8 @@ func (p *Path) endOptional() {
 		i--
 	}
 	p.nodes = p.nodes[:i]
-	p.addNode(nodeLiteral, v)
+	p.addNode(t, v)
+	p.popOptional()
 }
 
 func (p *Path) endCapture() {
@@ -71,6 +94,39 @@ func (p *Path) endCapture() {
 	l := len(p.nodes)
 	i := l - 1
 
+	if p.nodes[i].t == nodeCaptureRest {
+		// {name...}, the rest of the path
+		name := p.nodes[i-1].s
+		p.addCatchAll(name)
+
+		v = fmt.Sprintf("(?P<%s>.+)", name)
+		p.nodes = p.nodes[:i-2]
+		p.addNode(nodeSpecial, v)
+		return
+	}
+
+	if p.nodes[i].t == nodeCaptureConstraint {
+		// {name<constraint>}, resolved as the pattern is parsed
+		name, constraint := p.nodes[i-1].s, p.nodes[i].s
+
+		c, ok := GetConstraint(constraint)
+		if !ok {
+			v = "[^/]+"
+			if p.err == nil {
+				p.err = fmt.Errorf("%q: constraint %q not registered", name, constraint)
+			}
+		} else {
+			v = c.Expr()
+		}
+
+		p.addTypedCapture(name, constraint)
+
+		v = fmt.Sprintf("(?P<%s>%s)", name, v)
+		p.nodes = p.nodes[:i-2]
+		p.addNode(nodeSpecial, v)
+		return
+	}
+
 	for {
 		if p.nodes[i].t == nodeCaptureOption {
 			s = append(s, p.nodes[i].s)
@@ -89,11 +145,18 @@ func (p *Path) endCapture() {
 		v = fmt.Sprintf("(%s)", strings.Join(s, "|"))
 	}
 
+	p.addCapture(p.nodes[i].s, s)
+
 	v = fmt.Sprintf("(?P<%s>%s)", p.nodes[i].s, v)
 	p.nodes = p.nodes[:i-1]
 	p.addNode(nodeSpecial, v)
 }
 
+// Err returns the first semantic error found while parsing the pattern
+func (p *Path) Err() error {
+	return p.err
+}
+
 func (p *Path) Literal() bool {
 	for _, np := range p.nodes {
 		if np.t != nodeLiteral {
@@ -133,6 +196,10 @@ func MustCompile(path string) (*regexp.Regexp, bool) {
 	}
 
 	peg.Execute()
+	if err := peg.Err(); err != nil {
+		log.Fatal(err)
+	}
+
 	r, leaf := peg.Result()
 	return regexp.MustCompile(r), leaf
 }
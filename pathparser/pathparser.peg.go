@@ -27,6 +27,8 @@ const (
 	rulevalues
 	ruleoption
 	rulestar
+	rulerest
+	ruleconstraint
 	ruleliteral
 	ruleliteral_chars
 	ruleslash
@@ -51,6 +53,8 @@ const (
 	ruleAction7
 	ruleAction8
 	ruleAction9
+	ruleAction10
+	ruleAction11
 )
 
 var rul3s = [...]string{
@@ -64,6 +68,8 @@ var rul3s = [...]string{
 	"values",
 	"option",
 	"star",
+	"rest",
+	"constraint",
 	"literal",
 	"literal_chars",
 	"slash",
@@ -88,6 +94,8 @@ var rul3s = [...]string{
 	"Action7",
 	"Action8",
 	"Action9",
+	"Action10",
+	"Action11",
 }
 
 type token32 struct {
@@ -204,7 +212,7 @@ type Peg struct {
 
 	Buffer string
 	buffer []rune
-	rules  [34]func() bool
+	rules  [38]func() bool
 	parse  func(rule ...int) error
 	reset  func()
 	Pretty bool
@@ -311,20 +319,24 @@ func (p *Peg) Execute() {
 		case ruleAction1:
 			p.addNode(nodeCaptureOption, text)
 		case ruleAction2:
-			p.addNode(nodeSpecial, "[^/]+")
+			p.addStar()
 		case ruleAction3:
-			p.addNode(nodeLiteral, text)
+			p.addNode(nodeCaptureRest, "")
 		case ruleAction4:
-			p.addNode(nodeLiteral, "\\.")
+			p.addNode(nodeCaptureConstraint, text)
 		case ruleAction5:
-			p.addNode(nodeLiteral, "/")
+			p.addLiteral(text)
 		case ruleAction6:
-			p.beginOptional()
+			p.addLiteral(".")
 		case ruleAction7:
-			p.endOptional()
+			p.addLiteral("/")
 		case ruleAction8:
-			p.beginCapture()
+			p.beginOptional()
 		case ruleAction9:
+			p.endOptional()
+		case ruleAction10:
+			p.beginCapture()
+		case ruleAction11:
 			p.endCapture()
 
 		}
@@ -496,7 +508,7 @@ func (p *Peg) Init(options ...func(*Peg) error) error {
 								}
 								position++
 								{
-									add(ruleAction8, position)
+									add(ruleAction10, position)
 								}
 								add(rulebc, position14)
 							}
@@ -526,176 +538,235 @@ func (p *Peg) Init(options ...func(*Peg) error) error {
 							}
 							{
 								position21, tokenIndex21 := position, tokenIndex
-								if buffer[position] != rune(':') {
-									goto l21
-								}
-								position++
 								{
-									position23 := position
-									if !_rules[ruleoption]() {
-										goto l21
-									}
-								l24:
-									{
-										position25, tokenIndex25 := position, tokenIndex
-										if buffer[position] != rune('|') {
-											goto l25
+									switch buffer[position] {
+									case ':':
+										if buffer[position] != rune(':') {
+											goto l21
 										}
 										position++
-										if !_rules[ruleoption]() {
-											goto l25
+										{
+											position24 := position
+											if !_rules[ruleoption]() {
+												goto l21
+											}
+										l25:
+											{
+												position26, tokenIndex26 := position, tokenIndex
+												if buffer[position] != rune('|') {
+													goto l26
+												}
+												position++
+												if !_rules[ruleoption]() {
+													goto l26
+												}
+												goto l25
+											l26:
+												position, tokenIndex = position26, tokenIndex26
+											}
+											add(rulevalues, position24)
+										}
+									case '<':
+										{
+											position27 := position
+											if buffer[position] != rune('<') {
+												goto l21
+											}
+											position++
+											{
+												position28 := position
+												if !_rules[ruleset1]() {
+													goto l21
+												}
+											l29:
+												{
+													position30, tokenIndex30 := position, tokenIndex
+													if !_rules[ruleset1]() {
+														goto l30
+													}
+													goto l29
+												l30:
+													position, tokenIndex = position30, tokenIndex30
+												}
+												add(rulePegText, position28)
+											}
+											if buffer[position] != rune('>') {
+												goto l21
+											}
+											position++
+											{
+												add(ruleAction4, position)
+											}
+											add(ruleconstraint, position27)
+										}
+									default:
+										{
+											position32 := position
+											if buffer[position] != rune('.') {
+												goto l21
+											}
+											position++
+											if buffer[position] != rune('.') {
+												goto l21
+											}
+											position++
+											if buffer[position] != rune('.') {
+												goto l21
+											}
+											position++
+											{
+												add(ruleAction3, position)
+											}
+											add(rulerest, position32)
 										}
-										goto l24
-									l25:
-										position, tokenIndex = position25, tokenIndex25
 									}
-									add(rulevalues, position23)
 								}
+
 								goto l22
 							l21:
 								position, tokenIndex = position21, tokenIndex21
 							}
 						l22:
 							{
-								position26 := position
+								position34 := position
 								if buffer[position] != rune('}') {
 									goto l8
 								}
 								position++
 								{
-									add(ruleAction9, position)
+									add(ruleAction11, position)
 								}
-								add(ruleec, position26)
+								add(ruleec, position34)
 							}
 							add(rulecapture, position13)
 						}
 					case '[':
 						{
-							position28 := position
+							position36 := position
 							{
-								position29 := position
+								position37 := position
 								if buffer[position] != rune('[') {
 									goto l8
 								}
 								position++
 								{
-									add(ruleAction6, position)
+									add(ruleAction8, position)
 								}
-								add(rulebo, position29)
+								add(rulebo, position37)
 							}
 							if !_rules[ruleexpr]() {
 								goto l8
 							}
-						l31:
+						l39:
 							{
-								position32, tokenIndex32 := position, tokenIndex
+								position40, tokenIndex40 := position, tokenIndex
 								if !_rules[rulesegment]() {
-									goto l32
+									goto l40
 								}
-								goto l31
-							l32:
-								position, tokenIndex = position32, tokenIndex32
+								goto l39
+							l40:
+								position, tokenIndex = position40, tokenIndex40
 							}
 							{
-								position33 := position
+								position41 := position
 								if buffer[position] != rune(']') {
 									goto l8
 								}
 								position++
 								{
-									add(ruleAction7, position)
+									add(ruleAction9, position)
 								}
-								add(ruleeo, position33)
+								add(ruleeo, position41)
 							}
-							add(ruleoptional, position28)
+							add(ruleoptional, position36)
 						}
 					default:
 						{
-							position35 := position
+							position43 := position
 							{
-								position38 := position
+								position46 := position
 								{
-									position39, tokenIndex39 := position, tokenIndex
+									position47, tokenIndex47 := position, tokenIndex
 									{
-										position41 := position
+										position49 := position
 										if !_rules[ruleset1]() {
-											goto l40
+											goto l48
 										}
-									l42:
+									l50:
 										{
-											position43, tokenIndex43 := position, tokenIndex
+											position51, tokenIndex51 := position, tokenIndex
 											if !_rules[ruleset1]() {
-												goto l43
+												goto l51
 											}
-											goto l42
-										l43:
-											position, tokenIndex = position43, tokenIndex43
+											goto l50
+										l51:
+											position, tokenIndex = position51, tokenIndex51
 										}
-										add(rulePegText, position41)
+										add(rulePegText, position49)
 									}
 									{
-										add(ruleAction3, position)
+										add(ruleAction5, position)
 									}
-									goto l39
-								l40:
-									position, tokenIndex = position39, tokenIndex39
+									goto l47
+								l48:
+									position, tokenIndex = position47, tokenIndex47
 									if buffer[position] != rune('.') {
 										goto l8
 									}
 									position++
 									{
-										add(ruleAction4, position)
+										add(ruleAction6, position)
 									}
 								}
-							l39:
-								add(ruleliteral_chars, position38)
+							l47:
+								add(ruleliteral_chars, position46)
 							}
-						l36:
+						l44:
 							{
-								position37, tokenIndex37 := position, tokenIndex
+								position45, tokenIndex45 := position, tokenIndex
 								{
-									position46 := position
+									position54 := position
 									{
-										position47, tokenIndex47 := position, tokenIndex
+										position55, tokenIndex55 := position, tokenIndex
 										{
-											position49 := position
+											position57 := position
 											if !_rules[ruleset1]() {
-												goto l48
+												goto l56
 											}
-										l50:
+										l58:
 											{
-												position51, tokenIndex51 := position, tokenIndex
+												position59, tokenIndex59 := position, tokenIndex
 												if !_rules[ruleset1]() {
-													goto l51
+													goto l59
 												}
-												goto l50
-											l51:
-												position, tokenIndex = position51, tokenIndex51
+												goto l58
+											l59:
+												position, tokenIndex = position59, tokenIndex59
 											}
-											add(rulePegText, position49)
+											add(rulePegText, position57)
 										}
 										{
-											add(ruleAction3, position)
+											add(ruleAction5, position)
 										}
-										goto l47
-									l48:
-										position, tokenIndex = position47, tokenIndex47
+										goto l55
+									l56:
+										position, tokenIndex = position55, tokenIndex55
 										if buffer[position] != rune('.') {
-											goto l37
+											goto l45
 										}
 										position++
 										{
-											add(ruleAction4, position)
+											add(ruleAction6, position)
 										}
 									}
-								l47:
-									add(ruleliteral_chars, position46)
+								l55:
+									add(ruleliteral_chars, position54)
 								}
-								goto l36
-							l37:
-								position, tokenIndex = position37, tokenIndex37
+								goto l44
+							l45:
+								position, tokenIndex = position45, tokenIndex45
 							}
-							add(ruleliteral, position35)
+							add(ruleliteral, position43)
 						}
 					}
 				}
@@ -709,25 +780,25 @@ func (p *Peg) Init(options ...func(*Peg) error) error {
 		},
 		/* 2 segment <- <(slash expr)> */
 		func() bool {
-			position54, tokenIndex54 := position, tokenIndex
+			position62, tokenIndex62 := position, tokenIndex
 			{
-				position55 := position
+				position63 := position
 				if !_rules[ruleslash]() {
-					goto l54
+					goto l62
 				}
 				if !_rules[ruleexpr]() {
-					goto l54
+					goto l62
 				}
-				add(rulesegment, position55)
+				add(rulesegment, position63)
 			}
 			return true
-		l54:
-			position, tokenIndex = position54, tokenIndex54
+		l62:
+			position, tokenIndex = position62, tokenIndex62
 			return false
 		},
 		/* 3 optional <- <(bo expr segment* eo)> */
 		nil,
-		/* 4 capture <- <(bc name (':' values)? ec)> */
+		/* 4 capture <- <(bc name ((&(':') (':' values)) | (&('<') constraint) | (&('.') rest))? ec)> */
 		nil,
 		/* 5 name <- <(<(alpha set0*)> Action0)> */
 		nil,
@@ -735,238 +806,246 @@ func (p *Peg) Init(options ...func(*Peg) error) error {
 		nil,
 		/* 7 option <- <(<any+> Action1)> */
 		func() bool {
-			position60, tokenIndex60 := position, tokenIndex
+			position68, tokenIndex68 := position, tokenIndex
 			{
-				position61 := position
+				position69 := position
 				{
-					position62 := position
+					position70 := position
 					{
-						position65 := position
+						position73 := position
 						{
 							switch buffer[position] {
 							case '\\':
 								if buffer[position] != rune('\\') {
-									goto l60
+									goto l68
 								}
 								position++
 							case '.':
 								if buffer[position] != rune('.') {
-									goto l60
+									goto l68
 								}
 								position++
 							default:
 								if !_rules[ruleset1]() {
-									goto l60
+									goto l68
 								}
 							}
 						}
 
-						add(ruleany, position65)
+						add(ruleany, position73)
 					}
-				l63:
+				l71:
 					{
-						position64, tokenIndex64 := position, tokenIndex
+						position72, tokenIndex72 := position, tokenIndex
 						{
-							position67 := position
+							position75 := position
 							{
 								switch buffer[position] {
 								case '\\':
 									if buffer[position] != rune('\\') {
-										goto l64
+										goto l72
 									}
 									position++
 								case '.':
 									if buffer[position] != rune('.') {
-										goto l64
+										goto l72
 									}
 									position++
 								default:
 									if !_rules[ruleset1]() {
-										goto l64
+										goto l72
 									}
 								}
 							}
 
-							add(ruleany, position67)
+							add(ruleany, position75)
 						}
-						goto l63
-					l64:
-						position, tokenIndex = position64, tokenIndex64
+						goto l71
+					l72:
+						position, tokenIndex = position72, tokenIndex72
 					}
-					add(rulePegText, position62)
+					add(rulePegText, position70)
 				}
 				{
 					add(ruleAction1, position)
 				}
-				add(ruleoption, position61)
+				add(ruleoption, position69)
 			}
 			return true
-		l60:
-			position, tokenIndex = position60, tokenIndex60
+		l68:
+			position, tokenIndex = position68, tokenIndex68
 			return false
 		},
 		/* 8 star <- <('*' Action2)> */
 		nil,
-		/* 9 literal <- <literal_chars+> */
+		/* 9 rest <- <('.' '.' '.' Action3)> */
+		nil,
+		/* 10 constraint <- <('<' <set1+> '>' Action4)> */
+		nil,
+		/* 11 literal <- <literal_chars+> */
 		nil,
-		/* 10 literal_chars <- <((<set1+> Action3) / ('.' Action4))> */
+		/* 12 literal_chars <- <((<set1+> Action5) / ('.' Action6))> */
 		nil,
-		/* 11 slash <- <('/' Action5)> */
+		/* 13 slash <- <('/' Action7)> */
 		func() bool {
-			position73, tokenIndex73 := position, tokenIndex
+			position81, tokenIndex81 := position, tokenIndex
 			{
-				position74 := position
+				position82 := position
 				if buffer[position] != rune('/') {
-					goto l73
+					goto l81
 				}
 				position++
 				{
-					add(ruleAction5, position)
+					add(ruleAction7, position)
 				}
-				add(ruleslash, position74)
+				add(ruleslash, position82)
 			}
 			return true
-		l73:
-			position, tokenIndex = position73, tokenIndex73
+		l81:
+			position, tokenIndex = position81, tokenIndex81
 			return false
 		},
-		/* 12 bo <- <('[' Action6)> */
+		/* 14 bo <- <('[' Action8)> */
 		nil,
-		/* 13 eo <- <(']' Action7)> */
+		/* 15 eo <- <(']' Action9)> */
 		nil,
-		/* 14 bc <- <('{' Action8)> */
+		/* 16 bc <- <('{' Action10)> */
 		nil,
-		/* 15 ec <- <('}' Action9)> */
+		/* 17 ec <- <('}' Action11)> */
 		nil,
-		/* 16 alpha <- <([a-z] / [A-Z])> */
+		/* 18 alpha <- <([a-z] / [A-Z])> */
 		func() bool {
-			position80, tokenIndex80 := position, tokenIndex
+			position88, tokenIndex88 := position, tokenIndex
 			{
-				position81 := position
+				position89 := position
 				{
-					position82, tokenIndex82 := position, tokenIndex
+					position90, tokenIndex90 := position, tokenIndex
 					if c := buffer[position]; c < rune('a') || c > rune('z') {
-						goto l83
+						goto l91
 					}
 					position++
-					goto l82
-				l83:
-					position, tokenIndex = position82, tokenIndex82
+					goto l90
+				l91:
+					position, tokenIndex = position90, tokenIndex90
 					if c := buffer[position]; c < rune('A') || c > rune('Z') {
-						goto l80
+						goto l88
 					}
 					position++
 				}
-			l82:
-				add(rulealpha, position81)
+			l90:
+				add(rulealpha, position89)
 			}
 			return true
-		l80:
-			position, tokenIndex = position80, tokenIndex80
+		l88:
+			position, tokenIndex = position88, tokenIndex88
 			return false
 		},
-		/* 17 num <- <[0-9]> */
+		/* 19 num <- <[0-9]> */
 		nil,
-		/* 18 set0 <- <((&('_') '_') | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') num) | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z' | 'a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') alpha))> */
+		/* 20 set0 <- <((&('_') '_') | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') num) | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z' | 'a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') alpha))> */
 		func() bool {
-			position85, tokenIndex85 := position, tokenIndex
+			position93, tokenIndex93 := position, tokenIndex
 			{
-				position86 := position
+				position94 := position
 				{
 					switch buffer[position] {
 					case '_':
 						if buffer[position] != rune('_') {
-							goto l85
+							goto l93
 						}
 						position++
 					case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
 						{
-							position88 := position
+							position96 := position
 							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l85
+								goto l93
 							}
 							position++
-							add(rulenum, position88)
+							add(rulenum, position96)
 						}
 					default:
 						if !_rules[rulealpha]() {
-							goto l85
+							goto l93
 						}
 					}
 				}
 
-				add(ruleset0, position86)
+				add(ruleset0, position94)
 			}
 			return true
-		l85:
-			position, tokenIndex = position85, tokenIndex85
+		l93:
+			position, tokenIndex = position93, tokenIndex93
 			return false
 		},
-		/* 19 set1 <- <((&('%') '%') | (&(',') ',') | (&('+') '+') | (&('-') '-') | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9' | 'A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z' | '_' | 'a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') set0))> */
+		/* 21 set1 <- <((&('%') '%') | (&(',') ',') | (&('+') '+') | (&('-') '-') | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9' | 'A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z' | '_' | 'a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') set0))> */
 		func() bool {
-			position89, tokenIndex89 := position, tokenIndex
+			position97, tokenIndex97 := position, tokenIndex
 			{
-				position90 := position
+				position98 := position
 				{
 					switch buffer[position] {
 					case '%':
 						if buffer[position] != rune('%') {
-							goto l89
+							goto l97
 						}
 						position++
 					case ',':
 						if buffer[position] != rune(',') {
-							goto l89
+							goto l97
 						}
 						position++
 					case '+':
 						if buffer[position] != rune('+') {
-							goto l89
+							goto l97
 						}
 						position++
 					case '-':
 						if buffer[position] != rune('-') {
-							goto l89
+							goto l97
 						}
 						position++
 					default:
 						if !_rules[ruleset0]() {
-							goto l89
+							goto l97
 						}
 					}
 				}
 
-				add(ruleset1, position90)
+				add(ruleset1, position98)
 			}
 			return true
-		l89:
-			position, tokenIndex = position89, tokenIndex89
+		l97:
+			position, tokenIndex = position97, tokenIndex97
 			return false
 		},
-		/* 20 any <- <((&('\\') '\\') | (&('.') '.') | (&('%' | '+' | ',' | '-' | '0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9' | 'A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z' | '_' | 'a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') set1))> */
+		/* 22 any <- <((&('\\') '\\') | (&('.') '.') | (&('%' | '+' | ',' | '-' | '0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9' | 'A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z' | '_' | 'a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') set1))> */
+		nil,
+		/* 23 eos <- <!.> */
+		nil,
 		nil,
-		/* 21 eos <- <!.> */
+		/* 26 Action0 <- <{ p.addNode(nodeCaptureIdentifier, text) }> */
 		nil,
+		/* 27 Action1 <- <{ p.addNode(nodeCaptureOption, text) }> */
 		nil,
-		/* 24 Action0 <- <{ p.addNode(nodeCaptureIdentifier, text) }> */
+		/* 28 Action2 <- <{ p.addStar() }> */
 		nil,
-		/* 25 Action1 <- <{ p.addNode(nodeCaptureOption, text) }> */
+		/* 29 Action3 <- <{ p.addNode(nodeCaptureRest, "") }> */
 		nil,
-		/* 26 Action2 <- <{ p.addNode(nodeSpecial, "[^/]+") }> */
+		/* 30 Action4 <- <{ p.addNode(nodeCaptureConstraint, text) }> */
 		nil,
-		/* 27 Action3 <- <{ p.addNode(nodeLiteral, text) }> */
+		/* 31 Action5 <- <{ p.addLiteral(text) }> */
 		nil,
-		/* 28 Action4 <- <{ p.addNode(nodeLiteral, "\\.") }> */
+		/* 32 Action6 <- <{ p.addLiteral(".") }> */
 		nil,
-		/* 29 Action5 <- <{ p.addNode(nodeLiteral, "/") }> */
+		/* 33 Action7 <- <{ p.addLiteral("/") }> */
 		nil,
-		/* 30 Action6 <- <{ p.beginOptional() }> */
+		/* 34 Action8 <- <{ p.beginOptional() }> */
 		nil,
-		/* 31 Action7 <- <{ p.endOptional() }> */
+		/* 35 Action9 <- <{ p.endOptional() }> */
 		nil,
-		/* 32 Action8 <- <{ p.beginCapture() }> */
+		/* 36 Action10 <- <{ p.beginCapture() }> */
 		nil,
-		/* 33 Action9 <- <{ p.endCapture() }> */
+		/* 37 Action11 <- <{ p.endCapture() }> */
 		nil,
 	}
 	p.rules = _rules
@@ -0,0 +1,309 @@
+package pathparser
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// PartType tells what a Part of a Template represents
+type PartType uint
+
+const (
+	// PartLiteral is literal text, "/" included
+	PartLiteral PartType = iota
+	// PartCapture is a named {capture}
+	PartCapture
+	// PartStar is an anonymous `*` segment
+	PartStar
+	// PartOptional is an [optional] group of parts
+	PartOptional
+	// PartCatchAll is a {name...} capture taking the rest of the path
+	PartCatchAll
+)
+
+// Part is an element of a parsed path pattern
+type Part struct {
+	Type       PartType
+	Value      string   // literal text or capture name
+	Options    []string // capture options, if any
+	Constraint string   // name of the Constraint of typed captures
+	Parts      []Part   // content of an optional group
+}
+
+// Template is the structured form of a path pattern
+type Template []Part
+
+func (p *Path) pushParts() {
+	p.parts = append(p.parts, nil)
+}
+
+func (p *Path) addPart(v Part) {
+	if len(p.parts) == 0 {
+		p.pushParts()
+	}
+
+	if p.rest && v.Type != PartOptional && p.err == nil {
+		// only closing optional groups can follow a {name...}
+		p.err = fmt.Errorf("%q: catch-all capture must be at the end of the pattern", v.Value)
+	}
+
+	i := len(p.parts) - 1
+	parts := p.parts[i]
+
+	if l := len(parts); l > 0 && v.Type == PartLiteral && v.Value != "/" {
+		// merge consecutive literal text, but keep slashes apart
+		if last := &parts[l-1]; last.Type == PartLiteral && last.Value != "/" {
+			last.Value += v.Value
+			return
+		}
+	}
+
+	p.parts[i] = append(parts, v)
+}
+
+func (p *Path) addCapture(name string, options []string) {
+	v := Part{
+		Type:  PartCapture,
+		Value: name,
+	}
+
+	// options were collected backwards
+	for i := len(options) - 1; i >= 0; i-- {
+		v.Options = append(v.Options, options[i])
+	}
+
+	p.addPart(v)
+}
+
+func (p *Path) addTypedCapture(name string, constraint string) {
+	p.addPart(Part{
+		Type:       PartCapture,
+		Value:      name,
+		Constraint: constraint,
+	})
+}
+
+func (p *Path) addCatchAll(name string) {
+	p.addPart(Part{
+		Type:  PartCatchAll,
+		Value: name,
+	})
+	p.rest = true
+}
+
+func (p *Path) popOptional() {
+	l := len(p.parts) - 1
+	v := Part{
+		Type:  PartOptional,
+		Parts: p.parts[l],
+	}
+	p.parts = p.parts[:l]
+
+	// like the regular expression, the optional group
+	// takes the slash preceding it
+	l--
+	if parts := p.parts[l]; len(parts) > 0 {
+		if last := parts[len(parts)-1]; last.Type == PartLiteral && last.Value == "/" {
+			p.parts[l] = parts[:len(parts)-1]
+			v.Parts = append([]Part{last}, v.Parts...)
+		}
+	}
+
+	p.addPart(v)
+}
+
+// Template returns the structured form of the parsed pattern
+func (p *Path) Template() Template {
+	if len(p.parts) > 0 {
+		return Template(p.parts[0])
+	}
+	return nil
+}
+
+// Parse parses a path pattern
+func Parse(path string) (*Path, error) {
+	peg := &Peg{Buffer: path}
+	peg.Init()
+
+	if err := peg.Parse(); err != nil {
+		return nil, peg.SyntaxError(err)
+	}
+
+	peg.Execute()
+	if err := peg.Err(); err != nil {
+		return nil, err
+	}
+
+	return &peg.Path, nil
+}
+
+// Captures returns the names of all the captures of the Template,
+// including those within optional groups
+func (t Template) Captures() []string {
+	var names []string
+
+	for _, v := range t {
+		switch v.Type {
+		case PartCapture, PartCatchAll:
+			names = append(names, v.Value)
+		case PartOptional:
+			names = append(names, Template(v.Parts).Captures()...)
+		}
+	}
+
+	return names
+}
+
+// Expand renders the Template into a concrete path using the given values
+// for the captures. `*` segments take the value of the "*" key, and
+// {name...} captures can take values containing slashes.
+// Optional groups are only rendered when values for all their
+// captures are provided.
+func (t Template) Expand(values map[string]interface{}) (string, error) {
+	var b strings.Builder
+
+	if err := t.expand(&b, values); err != nil {
+		return "", err
+	}
+
+	return b.String(), nil
+}
+
+func (t Template) expand(b *strings.Builder, values map[string]interface{}) error {
+	for _, v := range t {
+		switch v.Type {
+		case PartLiteral:
+			b.WriteString(v.Value)
+		case PartCapture, PartStar, PartCatchAll:
+			s, err := v.expand(values)
+			if err != nil {
+				return err
+			}
+			b.WriteString(s)
+		case PartOptional:
+			if Template(v.Parts).complete(values) {
+				if err := Template(v.Parts).expand(b, values); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// complete tells if an optional group has captures and all of them are provided
+func (t Template) complete(values map[string]interface{}) bool {
+	var found bool
+
+	for _, v := range t {
+		switch v.Type {
+		case PartStar:
+			if _, ok := values["*"]; !ok {
+				return false
+			}
+			found = true
+		case PartCapture, PartCatchAll:
+			if _, ok := values[v.Value]; !ok {
+				return false
+			}
+			found = true
+		case PartOptional:
+			if Template(v.Parts).complete(values) {
+				found = true
+			}
+		}
+	}
+
+	return found
+}
+
+func (v Part) expand(values map[string]interface{}) (string, error) {
+	name := v.Value
+	if v.Type == PartStar {
+		name = "*"
+	}
+
+	x, ok := values[name]
+	if !ok {
+		return "", fmt.Errorf("%q: value missing", name)
+	}
+
+	s, err := v.Format(x)
+	if err != nil {
+		return "", err
+	} else if err := v.Validate(s); err != nil {
+		return "", err
+	}
+
+	if v.Type == PartCatchAll {
+		// escape segment by segment
+		segments := strings.Split(s, "/")
+		for i, x := range segments {
+			segments[i] = url.PathEscape(x)
+		}
+		return strings.Join(segments, "/"), nil
+	}
+
+	return url.PathEscape(s), nil
+}
+
+// Format renders a value for a capture
+func (v Part) Format(x interface{}) (string, error) {
+	if s, ok := x.(string); ok {
+		return s, nil
+	}
+
+	if v.Constraint != "" {
+		if c, ok := GetConstraint(v.Constraint); !ok {
+			return "", fmt.Errorf("%q: constraint %q not registered", v.Value, v.Constraint)
+		} else if f, ok := c.(ConstraintFormatter); ok {
+			return f.Format(x)
+		}
+	}
+
+	return fmt.Sprint(x), nil
+}
+
+// Validate checks if a value is acceptable for a capture or `*` segment
+func (v Part) Validate(s string) error {
+	var name string
+
+	switch v.Type {
+	case PartStar:
+		name = "*"
+	case PartCapture:
+		name = v.Value
+	case PartCatchAll:
+		// slashes welcomed
+		if s == "" {
+			return fmt.Errorf("%q: invalid value %q", v.Value, s)
+		}
+		return nil
+	default:
+		return nil
+	}
+
+	if s == "" || strings.ContainsRune(s, '/') {
+		return fmt.Errorf("%q: invalid value %q", name, s)
+	}
+
+	if v.Constraint != "" {
+		if c, ok := GetConstraint(v.Constraint); !ok {
+			return fmt.Errorf("%q: constraint %q not registered", name, v.Constraint)
+		} else if !c.Match(s) {
+			return fmt.Errorf("%q: %q isn't a valid %s", name, s, v.Constraint)
+		}
+	} else if len(v.Options) > 0 {
+		re, err := regexp.Compile(fmt.Sprintf("^(%s)$", strings.Join(v.Options, "|")))
+		if err != nil {
+			return err
+		} else if !re.MatchString(s) {
+			return fmt.Errorf("%q: %q doesn't match %q", name, s, v.Options)
+		}
+	}
+
+	return nil
+}
@@ -6,14 +6,17 @@ import (
 )
 
 // check request is a exact match (leaf, not intermediate router)
-// and makes sure there is no trailing slash
+// and makes sure there is no trailing slash, unless the PathPolicy
+// of the router accepted it
 func DefaultResourceChecker(ctx context.Context) (context.Context, error) {
 	if rctx := context.RouteContext(ctx); rctx != nil {
 		switch rctx.RoutePath {
 		case "":
 			break
 		case "/":
-			return nil, errors.NewSeeOther(rctx.RoutePrefix)
+			if !rctx.TrailingSlash {
+				return nil, errors.NewSeeOther(rctx.RoutePrefix)
+			}
 		default:
 			return nil, errors.ErrNotFound
 		}
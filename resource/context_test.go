@@ -0,0 +1,71 @@
+package resource
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.sancus.dev/web/context"
+	"go.sancus.dev/web/errors"
+	"go.sancus.dev/web/router"
+)
+
+type getter struct{}
+
+func (getter) Get(w http.ResponseWriter, r *http.Request) error {
+	fmt.Fprint(w, "ok")
+	return nil
+}
+
+func TestDefaultResourceChecker(t *testing.T) {
+	rctx := context.NewRouteContext("/foo", "/")
+	ctx := context.WithRouteContext(nil, rctx)
+
+	if _, err := DefaultResourceChecker(ctx); err == nil {
+		t.Error("trailing slash accepted")
+	} else if p, ok := errors.AsRedirect(err); !ok || p.Location() != "/foo" {
+		t.Errorf("unexpected %v", err)
+	}
+
+	rctx.TrailingSlash = true
+	if _, err := DefaultResourceChecker(ctx); err != nil {
+		t.Errorf("trailing slash accepted by the router refused: %v", err)
+	}
+}
+
+func TestTrailingSlash(t *testing.T) {
+	for _, tc := range []struct {
+		policy  router.SlashPolicy
+		pattern string
+		path    string
+		code    int
+		loc     string
+	}{
+		{router.SlashDefault, "/foo", "/foo/", 404, ""},
+		{router.SlashDefault, "/foo/", "/foo", 308, "foo/"},
+		{router.SlashAdd, "/foo", "/foo", 200, ""},
+		{router.SlashAdd, "/foo", "/foo/", 404, ""},
+		{router.SlashAdd, "/foo/", "/foo", 308, "/foo/"},
+		{router.SlashAdd, "/foo/", "/foo/", 200, ""},
+		{router.SlashStrict, "/foo/", "/foo", 404, ""},
+		{router.SlashStrict, "/foo/", "/foo/", 200, ""},
+		{router.SlashRemove, "/foo", "/foo/", 308, "/foo"},
+		{router.SlashRemove, "/foo/", "/foo", 200, ""},
+		{router.SlashRemove, "/foo/", "/foo/", 303, "/foo"},
+		{router.SlashMatch, "/foo", "/foo/", 200, ""},
+		{router.SlashMatch, "/foo/", "/foo", 200, ""},
+	} {
+		m := router.NewRouter(nil).(*router.Mux)
+		m.WithPathPolicy(router.PathPolicy{Slash: tc.policy})
+		m.Handle(tc.pattern, NewResource(getter{}, nil, nil))
+
+		rec := httptest.NewRecorder()
+		m.ServeHTTP(rec, httptest.NewRequest("GET", tc.path, nil))
+
+		if rec.Code != tc.code || rec.Header().Get("Location") != tc.loc {
+			t.Errorf("%v %q %q: %v %q", tc.policy, tc.pattern, tc.path,
+				rec.Code, rec.Header().Get("Location"))
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package resource
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+
+	"go.sancus.dev/web/openapi"
+)
+
+// Summarizer describes the operations of a resource
+type Summarizer interface {
+	Summary(method string) (summary string, description string)
+}
+
+// RequestSchemer describes the body an operation accepts
+type RequestSchemer interface {
+	RequestSchema(method string) (mimetype string, schema *openapi.Schema)
+}
+
+// ResponseSchemer describes the body an operation returns on success
+type ResponseSchemer interface {
+	ResponseSchema(method string) (mimetype string, schema *openapi.Schema)
+}
+
+// ErrorStatuser lists the error status codes an operation can return
+type ErrorStatuser interface {
+	ErrorStatus(method string) []int
+}
+
+// Methods returns the methods the Resource handles
+func (m *Resource) Methods() []string {
+	s := make([]string, 0, len(m.h))
+	for k := range m.h {
+		s = append(s, k)
+	}
+
+	sort.Strings(s)
+	return s
+}
+
+// Describe fills an OpenAPI Operation using the optional
+// interfaces implemented by the resource
+func (m *Resource) Describe(method string, op *openapi.Operation) {
+	if p, ok := m.v.(Summarizer); ok {
+		op.Summary, op.Description = p.Summary(method)
+	}
+
+	if p, ok := m.v.(RequestSchemer); ok {
+		if mimetype, schema := p.RequestSchema(method); mimetype != "" {
+			op.RequestBody = &openapi.RequestBody{
+				Required: true,
+				Content: map[string]*openapi.MediaType{
+					mimetype: {Schema: schema},
+				},
+			}
+		}
+	}
+
+	if p, ok := m.v.(ResponseSchemer); ok {
+		if mimetype, schema := p.ResponseSchema(method); mimetype != "" {
+			code := strconv.Itoa(http.StatusOK)
+
+			op.Responses[code] = &openapi.Response{
+				Description: http.StatusText(http.StatusOK),
+				Content: map[string]*openapi.MediaType{
+					mimetype: {Schema: schema},
+				},
+			}
+		}
+	}
+
+	if p, ok := m.v.(ErrorStatuser); ok {
+		for _, code := range p.ErrorStatus(method) {
+			op.Responses[strconv.Itoa(code)] = &openapi.Response{
+				Description: http.StatusText(code),
+			}
+		}
+	}
+}
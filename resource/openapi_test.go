@@ -0,0 +1,62 @@
+package resource
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"go.sancus.dev/web/openapi"
+)
+
+type item struct{}
+
+func (item) Get(w http.ResponseWriter, r *http.Request) error  { return nil }
+func (item) Post(w http.ResponseWriter, r *http.Request) error { return nil }
+
+func (item) Summary(method string) (string, string) {
+	return method + " item", "about the item"
+}
+
+func (item) RequestSchema(method string) (string, *openapi.Schema) {
+	if method == "POST" {
+		return "application/json", &openapi.Schema{Type: "object"}
+	}
+	return "", nil
+}
+
+func (item) ResponseSchema(method string) (string, *openapi.Schema) {
+	return "application/json", &openapi.Schema{Ref: "#/components/schemas/Item"}
+}
+
+func (item) ErrorStatus(method string) []int {
+	return []int{http.StatusNotFound}
+}
+
+func TestDescribe(t *testing.T) {
+	m := NewResource(item{}, nil, nil)
+
+	if s := m.Methods(); !reflect.DeepEqual(s, []string{"GET", "HEAD", "OPTIONS", "POST"}) {
+		t.Errorf("methods %q", s)
+	}
+
+	for _, method := range []string{"GET", "POST"} {
+		op := &openapi.Operation{
+			Responses: make(map[string]*openapi.Response),
+		}
+		m.Describe(method, op)
+
+		if op.Summary != method+" item" || op.Description != "about the item" {
+			t.Errorf("%s: summary %q %q", method, op.Summary, op.Description)
+		}
+
+		if (op.RequestBody != nil) != (method == "POST") {
+			t.Errorf("%s: request body %+v", method, op.RequestBody)
+		}
+
+		if r := op.Responses["200"]; r == nil || r.Content["application/json"].Schema.Ref == "" {
+			t.Errorf("%s: 200 %+v", method, r)
+		} else if r := op.Responses["404"]; r == nil || r.Description != "Not Found" {
+			t.Errorf("%s: 404 %+v", method, r)
+		}
+	}
+}
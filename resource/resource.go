@@ -10,6 +10,7 @@ import (
 )
 
 type Resource struct {
+	v     interface{}
 	h     map[string]web.HandlerFunc
 	eh    web.ErrorHandlerFunc
 	check ContextChecker
@@ -55,6 +56,7 @@ func (m *Resource) Init(v interface{}, eh web.ErrorHandlerFunc, check ContextChe
 	}
 
 	*m = Resource{
+		v:     v,
 		h:     make(map[string]web.HandlerFunc),
 		eh:    eh,
 		check: check,
@@ -10,8 +10,11 @@ import (
 type Chain struct {
 	router
 
-	mux   *Mux
-	chain []web.MiddlewareHandlerFunc
+	mux    *Mux
+	chain  []web.MiddlewareHandlerFunc
+	name   string
+	preds  []Predicate
+	groups []string
 }
 
 func (m *Chain) init(mux *Mux) {
@@ -22,19 +25,65 @@ func (m *Chain) init(mux *Mux) {
 func (m *Chain) getNode(path string) *node {
 	n := m.mux.getNode(path)
 	n.with(m.chain...)
+	n.group(m.groups...)
+	n.when(m.preds...)
+	m.mux.setName(m.name, n)
 	return n
 }
 
+func (m *Chain) Named(name string) MiniRouter {
+	m2 := &Chain{
+		chain:  m.chain,
+		name:   name,
+		preds:  m.preds,
+		groups: m.groups,
+	}
+	m2.init(m.mux)
+	return m2
+}
+
+// When restricts the routes registered through the returned MiniRouter
+// to requests matching all the given predicates
+func (m *Chain) When(preds ...Predicate) MiniRouter {
+	m2 := &Chain{
+		chain:  m.chain,
+		name:   m.name,
+		preds:  append(append([]Predicate{}, m.preds...), preds...),
+		groups: m.groups,
+	}
+	m2.init(m.mux)
+	return m2
+}
+
+// With returns a new MiniRouter adding the middleware to the chain.
+// The receiver isn't modified
 func (m *Chain) With(f web.MiddlewareHandlerFunc) MiniRouter {
 	if f != nil {
 		m2 := &Chain{
-			chain: append(m.chain, f),
+			chain:  appendChain(m.chain, []web.MiddlewareHandlerFunc{f}),
+			name:   m.name,
+			preds:  m.preds,
+			groups: m.groups,
 		}
 		m2.init(m.mux)
+		return m2
 	}
 	return m
 }
 
+// group returns a new Chain recording the name of a Group
+// applied to it, for Inspect()
+func (m *Chain) group(name string) *Chain {
+	m2 := &Chain{
+		chain:  m.chain,
+		name:   m.name,
+		preds:  m.preds,
+		groups: append(append([]string{}, m.groups...), name),
+	}
+	m2.init(m.mux)
+	return m2
+}
+
 // Squash middleware chain
 func CompileChain(chain []web.MiddlewareHandlerFunc, h http.Handler) http.Handler {
 	l := len(chain)
@@ -0,0 +1,123 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.sancus.dev/web"
+)
+
+// tag appends its name to the X-Trace header of the response
+func tag(name string) web.MiddlewareHandlerFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("X-Trace", name)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func trace(t *testing.T, h http.Handler, path string) string {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", path, nil))
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("%s: %v", path, rec.Code)
+	}
+	return strings.Join(rec.Header().Values("X-Trace"), ",")
+}
+
+func TestChainWith(t *testing.T) {
+	h := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	m := NewRouter(nil)
+
+	// siblings sharing a base with spare capacity
+	base := m.With(tag("a")).With(tag("b")).With(tag("c"))
+	base.With(tag("x")).HandleFunc("/x", h)
+	base.With(tag("y")).HandleFunc("/y", h)
+	base.HandleFunc("/base", h)
+
+	for path, expected := range map[string]string{
+		"/x":    "a,b,c,x",
+		"/y":    "a,b,c,y",
+		"/base": "a,b,c",
+	} {
+		if s := trace(t, m, path); s != expected {
+			t.Errorf("%s: %q instead of %q", path, s, expected)
+		}
+	}
+}
+
+func TestGroup(t *testing.T) {
+	h := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	auth := NewGroup("auth", tag("session"), tag("auth"))
+	admin := auth.With(tag("admin")).Named("admin")
+	api := auth.With(tag("api"))
+
+	if auth.Name() != "auth" || admin.Name() != "admin" || len(auth.Middleware()) != 2 {
+		t.Fatalf("group modified: %q %v", auth.Name(), len(auth.Middleware()))
+	}
+
+	m := NewRouter(nil)
+	m.Use(tag("log"))
+
+	admin.Route(m, "/admin", func(r Router) {
+		r.HandleFunc("/", h)
+	})
+	api.Route(m, "/api", func(r Router) {
+		r.HandleFunc("/items", h)
+	})
+	auth.Route(m.With(tag("outer")), "/me", func(r Router) {
+		r.HandleFunc("/", h)
+	})
+	auth.Apply(m).HandleFunc("/settings", h)
+
+	for path, expected := range map[string]string{
+		"/admin/":    "log,session,auth,admin",
+		"/api/items": "log,session,auth,api",
+		"/me/":       "log,outer,session,auth",
+		"/settings":  "log,session,auth",
+	} {
+		if s := trace(t, m, path); s != expected {
+			t.Errorf("%s: %q instead of %q", path, s, expected)
+		}
+	}
+
+	if s := trace(t, admin.Handler(http.HandlerFunc(h)), "/"); s != "session,auth,admin" {
+		t.Errorf("Handler: %q", s)
+	}
+
+	groups := make(map[string]string)
+	walkInfo(m.(*Mux).Inspect(), func(ri *RouteInfo) {
+		for _, v := range ri.Handlers {
+			groups[ri.Path()] = strings.Join(v.Groups, ",")
+		}
+	})
+
+	for path, expected := range map[string]string{
+		"/admin/":    "admin",
+		"/api/items": "auth",
+		"/me/":       "auth",
+		"/settings":  "auth",
+	} {
+		if s := groups[path]; s != expected {
+			t.Errorf("%s: groups %q instead of %q", path, s, expected)
+		}
+	}
+}
+
+// walkInfo calls fn on every route of the tree
+func walkInfo(ri *RouteInfo, fn func(*RouteInfo)) {
+	fn(ri)
+	for _, sub := range ri.Routes {
+		walkInfo(sub, fn)
+	}
+}
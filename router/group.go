@@ -0,0 +1,78 @@
+package router
+
+import (
+	"net/http"
+
+	"go.sancus.dev/web"
+)
+
+// Group is a reusable list of middleware. Groups are immutable,
+// With() returns a new one, so they can be shared by any number
+// of routes and routers.
+type Group struct {
+	name  string
+	chain []web.MiddlewareHandlerFunc
+}
+
+// NewGroup creates a Group of middleware, outermost first
+func NewGroup(name string, middleware ...web.MiddlewareHandlerFunc) *Group {
+	return (&Group{name: name}).With(middleware...)
+}
+
+// With returns a new Group with the middleware appended
+func (g *Group) With(middleware ...web.MiddlewareHandlerFunc) *Group {
+	g2 := &Group{
+		name:  g.name,
+		chain: appendChain(g.chain, nil),
+	}
+
+	for _, f := range middleware {
+		if f != nil {
+			g2.chain = append(g2.chain, f)
+		}
+	}
+	return g2
+}
+
+// Named returns a copy of the Group with another name
+func (g *Group) Named(name string) *Group {
+	return &Group{
+		name:  name,
+		chain: g.chain,
+	}
+}
+
+func (g *Group) Name() string {
+	return g.name
+}
+
+// Middleware returns a copy of the chain, outermost first
+func (g *Group) Middleware() []web.MiddlewareHandlerFunc {
+	return appendChain(g.chain, nil)
+}
+
+// Handler wraps a handler with the middleware of the Group
+func (g *Group) Handler(h http.Handler) http.Handler {
+	return CompileChain(g.chain, h)
+}
+
+// Apply returns a MiniRouter whose registrations get the
+// middleware of the Group, after any the MiniRouter already had.
+// The name of the Group is shown by Inspect()
+func (g *Group) Apply(r MiniRouter) MiniRouter {
+	for _, f := range g.chain {
+		r = r.With(f)
+	}
+
+	if g.name != "" {
+		if c, ok := r.With(nil).(*Chain); ok {
+			r = c.group(g.name)
+		}
+	}
+	return r
+}
+
+// Route creates a subrouter using the middleware of the Group
+func (g *Group) Route(r MiniRouter, path string, fn func(Router)) Router {
+	return g.Apply(r).Route(path, fn)
+}
@@ -0,0 +1,252 @@
+package router
+
+import (
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+
+	"go.sancus.dev/web"
+	"go.sancus.dev/web/context"
+	"go.sancus.dev/web/errors"
+	"go.sancus.dev/web/pathparser"
+)
+
+// HostMux picks a Router by the Host of the request
+type HostMux struct {
+	Handler
+
+	hosts        map[string]*patternNode
+	segments     segNode
+	fallback     *node
+	errorHandler web.ErrorHandlerFunc
+}
+
+func NewHostRouter(h web.ErrorHandlerFunc) *HostMux {
+	if h == nil {
+		h = errors.HandleError
+	}
+
+	m := &HostMux{
+		hosts:        make(map[string]*patternNode),
+		errorHandler: h,
+	}
+
+	m.Handler = NewHandler(web.HandlerFunc(m.tryServeHTTP), nil, h)
+	return m
+}
+
+// Host returns the Router for a host pattern, creating it if needed.
+// Patterns are either exact names like "example.com", or use the path
+// syntax on each label, like "{tenant}.example.com" or "*.example.com".
+// A {name...} as first label takes any number of them, and "*" alone
+// catches any host not matched otherwise. Hosts are matched in lowercase.
+func (m *HostMux) Host(pattern string, fn func(Router)) Router {
+	var n *node
+
+	pattern = lowerHost(pattern)
+	if pattern == "*" {
+		n = m.fallback
+		if n == nil {
+			n = m.newNode(pattern)
+			m.fallback = n
+		}
+	} else if p, ok := m.hosts[pattern]; ok {
+		n = p.node
+	} else {
+		t, err := parseHost(pattern)
+		if err != nil {
+			panic(err)
+		}
+
+		n = m.newNode(pattern)
+		p = &patternNode{
+			node:     n,
+			seq:      len(m.hosts),
+			rank:     rankTemplate(t),
+			captures: t.Captures(),
+		}
+
+		if l := len(p.captures); l > maxCaptures {
+			panic(errors.New("%q: too many captures (%v > %v)", pattern, l, maxCaptures))
+		} else if err := m.segments.insert(p, t); err != nil {
+			panic(err)
+		}
+
+		m.hosts[pattern] = p
+	}
+
+	r := n.router()
+	if fn != nil {
+		fn(r)
+	}
+	return r
+}
+
+func (m *HostMux) newNode(pattern string) *node {
+	return &node{
+		Handler: NewRouter(m.errorHandler),
+		Pattern: pattern,
+	}
+}
+
+// hostPath turns a host name into a path, reversing the order
+// of the labels so the tree shares the domain instead of the
+// subdomain, "a.example.com" becomes "/com/example/a"
+func hostPath(host string) string {
+	labels := reverse(splitHost(host))
+	return "/" + strings.Join(labels, "/")
+}
+
+func reverse(s []string) []string {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+	return s
+}
+
+// splitHost splits a host name or pattern by its dots, except
+// those within {captures}
+func splitHost(host string) []string {
+	var labels []string
+	var depth, start int
+
+	for i := 0; i < len(host); i++ {
+		switch host[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+		case '.':
+			if depth == 0 {
+				labels = append(labels, host[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	return append(labels, host[start:])
+}
+
+// lowerHost lowercases the literal parts of a host pattern,
+// leaving the {captures} alone
+func lowerHost(pattern string) string {
+	var depth int
+
+	b := []byte(pattern)
+	for i, c := range b {
+		switch {
+		case c == '{':
+			depth++
+		case c == '}':
+			depth--
+		case depth == 0 && 'A' <= c && c <= 'Z':
+			b[i] = c + 'a' - 'A'
+		}
+	}
+	return string(b)
+}
+
+func parseHost(pattern string) (pathparser.Template, error) {
+	p, err := pathparser.Parse(hostPath(lowerHost(pattern)))
+	if err != nil {
+		return nil, errors.New("%q: invalid host pattern: %s", pattern, err)
+	}
+	return p.Template(), nil
+}
+
+// cleanHost removes port and trailing dot from a Host header
+func cleanHost(host string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+
+	host = strings.TrimSuffix(host, ".")
+	return strings.ToLower(host)
+}
+
+// Resolve finds the Router for a host, and the values captured from it
+func (m *HostMux) Resolve(host string, args map[string]interface{}) (Router, bool) {
+	host = cleanHost(host)
+
+	if host != "" {
+		sm := segMatch{path: hostPath(host)}
+		m.segments.match(&sm, 0, 0)
+
+		if p := sm.best; p != nil && sm.end == len(sm.path) {
+			if args != nil {
+				sm.export(args)
+
+				for k, v := range args {
+					if s, ok := v.(string); ok && strings.ContainsRune(s, '/') {
+						// {name...} took several labels, backwards
+						args[k] = strings.Join(reverse(strings.Split(s, "/")), ".")
+					}
+				}
+			}
+
+			return p.router(), true
+		}
+	}
+
+	if m.fallback != nil {
+		return m.fallback.router(), true
+	}
+
+	return nil, false
+}
+
+func (m *HostMux) tryServeHTTP(w http.ResponseWriter, r *http.Request) error {
+	args := make(map[string]interface{})
+
+	h, ok := m.Resolve(r.Host, args)
+	if !ok {
+		return errors.ErrNotFound
+	}
+
+	if len(args) > 0 {
+		// host captures go alongside the path ones
+		ctx, rctx, _ := context.GetRouteContextPath(r)
+
+		rctx = rctx.Clone()
+		for k, v := range args {
+			rctx.Set(k, v)
+		}
+
+		ctx = context.WithRouteContext(ctx, rctx)
+		r = r.WithContext(ctx)
+	}
+
+	return h.TryServeHTTP(w, r)
+}
+
+// Walk calls a given function for each node on the routers of
+// every host, with the host pattern prepended to the path
+func (m *HostMux) Walk(fn WalkFn) {
+	if fn == nil {
+		return
+	}
+
+	for _, p := range m.sorted() {
+		if p.router().walk(p.Pattern, fn) {
+			return
+		}
+	}
+
+	if m.fallback != nil {
+		m.fallback.router().walk(m.fallback.Pattern, fn)
+	}
+}
+
+// sorted returns the host patterns in the order they are considered
+func (m *HostMux) sorted() []*patternNode {
+	s := make([]*patternNode, 0, len(m.hosts))
+	for _, p := range m.hosts {
+		s = append(s, p)
+	}
+
+	sort.Slice(s, func(i, j int) bool {
+		return s[i].before(s[j])
+	})
+	return s
+}
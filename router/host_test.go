@@ -0,0 +1,82 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"go.sancus.dev/web/context"
+)
+
+func TestHostMux(t *testing.T) {
+	echo := func(name string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, "%s %v", name, context.RouteParams(r.Context()))
+		}
+	}
+
+	m := NewHostRouter(nil)
+	m.Host("Example.com", func(r Router) {
+		r.HandleFunc("/", echo("main"))
+	})
+	m.Host("{tenant}.example.com", func(r Router) {
+		r.HandleFunc("/{page}", echo("tenant"))
+	})
+	m.Host("{sub...}.example.org", func(r Router) {
+		r.HandleFunc("/", echo("org"))
+	})
+
+	for _, tc := range []struct {
+		host, path string
+		code       int
+		body       string
+	}{
+		{"example.com", "/", 200, "main map[]"},
+		{"EXAMPLE.COM", "/", 200, "main map[]"},
+		{"example.com:8080", "/", 200, "main map[]"},
+		{"example.com.", "/", 200, "main map[]"},
+		{"Acme.example.com", "/about", 200, "tenant map[page:about tenant:acme]"},
+		{"a.b.example.org", "/", 200, "org map[sub:a.b]"},
+		{"a.b.example.com", "/about", 404, ""},
+		{"example.net", "/", 404, ""},
+	} {
+		req := httptest.NewRequest("GET", tc.path, nil)
+		req.Host = tc.host
+		rec := httptest.NewRecorder()
+
+		m.ServeHTTP(rec, req)
+		if rec.Code != tc.code {
+			t.Errorf("%s%s: status %v", tc.host, tc.path, rec.Code)
+		} else if tc.code == 200 && rec.Body.String() != tc.body {
+			t.Errorf("%s%s: %q instead of %q", tc.host, tc.path, rec.Body.String(), tc.body)
+		}
+	}
+
+	// same Router regardless of the case used to register
+	if m.Host("EXAMPLE.com", nil) != m.Host("example.com", nil) {
+		t.Error("host patterns not case insensitive")
+	}
+
+	// Resolve
+	args := make(map[string]interface{})
+	r, ok := m.Resolve("Foo.Example.com:443", args)
+	if !ok || r != m.Host("{tenant}.example.com", nil) {
+		t.Errorf("resolved to %v, %v", r, ok)
+	} else if !reflect.DeepEqual(args, map[string]interface{}{"tenant": "foo"}) {
+		t.Errorf("captured %v", args)
+	}
+
+	if _, ok := m.Resolve("example.net", nil); ok {
+		t.Error("example.net resolved")
+	}
+
+	// fallback
+	m.Host("*", func(r Router) {
+		r.HandleFunc("/", echo("any"))
+	})
+	if _, ok := m.Resolve("example.net", nil); !ok {
+		t.Error("fallback not used")
+	}
+}
@@ -0,0 +1,232 @@
+package router
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"go.sancus.dev/web"
+	"go.sancus.dev/web/errors"
+	"go.sancus.dev/web/mimeparse"
+)
+
+// handlerInfo describes a handler given to a node
+type handlerInfo struct {
+	handler    interface{}
+	method     string
+	typ        string
+	middleware []web.MiddlewareHandlerFunc // With() chain
+	groups     []string                    // of the chain
+}
+
+// RouteInfo describes a node of the routing tree
+type RouteInfo struct {
+	Pattern    string        `json:"pattern"`
+	Prefix     string        `json:"prefix,omitempty"` // of the subrouter containing it
+	Name       string        `json:"name,omitempty"`
+	Methods    []string      `json:"methods,omitempty"`
+	Handlers   []HandlerInfo `json:"handlers,omitempty"`
+	Middleware int           `json:"middleware,omitempty"` // Use() depth, for routers
+	Groups     []string      `json:"groups,omitempty"`     // applied to the router, outermost first
+	Router     bool          `json:"router,omitempty"`
+	Routes     []*RouteInfo  `json:"routes,omitempty"`
+}
+
+// HandlerInfo describes a handler registered on a route
+type HandlerInfo struct {
+	Method     string      `json:"method"` // "*" for any
+	Type       string      `json:"type"`
+	Middleware int         `json:"middleware"`       // total depth, including routers
+	Chain      []string    `json:"chain,omitempty"`  // names of the middleware, outermost first
+	Groups     []string    `json:"groups,omitempty"` // names of the Groups applied, including routers
+	Handler    interface{} `json:"-"`                // as registered
+}
+
+// Path returns the full pattern of the route
+func (ri *RouteInfo) Path() string {
+	return ri.Prefix + ri.Pattern
+}
+
+// Inspect describes the routing tree of the Mux
+func (m *Mux) Inspect() *RouteInfo {
+	return m.inspect(&node{Pattern: "/*"}, "", nil, nil)
+}
+
+func (m *Mux) inspect(n *node, prefix string, chain, groups []string) *RouteInfo {
+	chain = appendNames(chain, m.node.middleware)
+	groups = appendGroups(groups, m.node.groups)
+
+	ri := &RouteInfo{
+		Pattern:    n.Pattern,
+		Prefix:     prefix,
+		Name:       n.Name,
+		Middleware: len(chain),
+		Groups:     groups,
+		Router:     true,
+	}
+
+	prefix = strings.TrimSuffix(ri.Path(), "/*")
+
+	m.eachNode(func(n *node) bool {
+		if sub := n.router(); sub != nil {
+			ri.Routes = append(ri.Routes, sub.inspect(n, prefix, chain, groups))
+		} else {
+			ri.Routes = append(ri.Routes, n.inspect(prefix, chain, groups))
+		}
+		return false
+	})
+
+	return ri
+}
+
+func (n *node) inspect(prefix string, chain, groups []string) *RouteInfo {
+	ri := &RouteInfo{
+		Pattern: n.Pattern,
+		Prefix:  prefix,
+		Name:    n.Name,
+	}
+
+	for _, v := range n.handlers {
+		s := appendNames(chain, v.middleware)
+
+		ri.Handlers = append(ri.Handlers, HandlerInfo{
+			Method:     v.method,
+			Type:       v.typ,
+			Middleware: len(s),
+			Chain:      s,
+			Groups:     appendGroups(groups, v.groups),
+			Handler:    v.handler,
+		})
+	}
+
+	ri.Methods = n.methods()
+	return ri
+}
+
+// methodHandler returns the MethodHandler of a node, if any
+func (n *node) methodHandler() *MethodHandler {
+	var h interface{} = n.Handler
+
+	switch v := h.(type) {
+	case *rawNode:
+		h = v.h
+	case *handler:
+		h = v.h1
+	}
+
+	v, _ := h.(*MethodHandler)
+	return v
+}
+
+// methods returns the methods explicitly handled
+func (m *MethodHandler) methods() []string {
+	s := make([]string, 0, len(m.handler))
+	for k, h := range m.handler {
+		if _, ok := h.(*errors.MethodNotAllowedError); !ok {
+			// not the memoized MethodNotAllowed
+			s = append(s, k)
+		}
+	}
+
+	sort.Strings(s)
+	return s
+}
+
+// typeName names the type of a handler, or the function for HandlerFuncs
+func typeName(h interface{}) string {
+	if h == nil {
+		return ""
+	}
+
+	v := reflect.ValueOf(h)
+	if v.Kind() == reflect.Func {
+		if f := runtime.FuncForPC(v.Pointer()); f != nil {
+			return f.Name()
+		}
+	}
+
+	return fmt.Sprintf("%T", h)
+}
+
+// appendNames returns a new slice with the names of the middleware added
+func appendNames(names []string, chain []web.MiddlewareHandlerFunc) []string {
+	out := make([]string, 0, len(names)+len(chain))
+	out = append(out, names...)
+	for _, f := range chain {
+		out = append(out, typeName(f))
+	}
+	return out
+}
+
+// appendGroups returns the names of both lists of Groups, nil if none
+func appendGroups(a, b []string) []string {
+	if len(a)+len(b) == 0 {
+		return nil
+	}
+	return append(append([]string{}, a...), b...)
+}
+
+// WriteText renders the tree as an aligned table
+func (ri *RouteInfo) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "PATTERN\tMETHOD\tHANDLER\tMIDDLEWARE\tNAME\tGROUPS\tCHAIN")
+	ri.writeText(tw)
+
+	return tw.Flush()
+}
+
+func (ri *RouteInfo) writeText(w io.Writer) {
+	if ri.Router {
+		fmt.Fprintf(w, "%s\t-\t(router)\t%v\t%s\t%s\t\n", ri.Path(), ri.Middleware, ri.Name,
+			strings.Join(ri.Groups, ","))
+
+		for _, sub := range ri.Routes {
+			sub.writeText(w)
+		}
+		return
+	}
+
+	for _, h := range ri.Handlers {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%v\t%s\t%s\t%s\n", ri.Path(), h.Method, h.Type, h.Middleware, ri.Name,
+			strings.Join(h.Groups, ","), strings.Join(h.Chain, " > "))
+	}
+}
+
+// DebugHandler serves the routing tree of the Mux as text or JSON,
+// as negotiated by the Accept header
+func (m *Mux) DebugHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var err error
+
+		ri := m.Inspect()
+
+		supported := []string{"text/plain", "application/json"}
+		mimetype := mimeparse.BestMatch(supported, r.Header.Get("Accept"))
+		if mimetype == "" {
+			mimetype = supported[0]
+		}
+
+		w.Header().Set("Content-Type", mimetype+"; charset=utf-8")
+
+		if mimetype == "application/json" {
+			enc := json.NewEncoder(w)
+			enc.SetIndent("", "  ")
+			err = enc.Encode(ri)
+		} else {
+			err = ri.WriteText(w)
+		}
+
+		if err != nil {
+			// likely a gone client, nothing else to do
+			log.Printf("%+v: %s", errors.Here(), err)
+		}
+	})
+}
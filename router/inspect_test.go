@@ -0,0 +1,96 @@
+package router
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.sancus.dev/web"
+)
+
+func TestInspect(t *testing.T) {
+	h := func(w http.ResponseWriter, r *http.Request) {}
+	mw := func(next http.Handler) http.Handler { return next }
+
+	m := NewRouter(nil).(*Mux)
+	m.Named("home").HandleFunc("/", h)
+	m.Route("/api", func(r Router) {
+		r.Use(web.MiddlewareHandlerFunc(mw))
+		r.MethodFunc("GET", "/items", h)
+		r.MethodFunc("POST", "/items", h)
+	})
+
+	ri := m.Inspect()
+	if !ri.Router || len(ri.Routes) != 2 {
+		t.Fatalf("unexpected %+v", ri)
+	}
+
+	var home, items *RouteInfo
+	for _, v := range ri.Routes {
+		switch {
+		case v.Pattern == "/":
+			home = v
+		case v.Router && len(v.Routes) == 1:
+			items = v.Routes[0]
+		}
+	}
+
+	if home == nil || home.Name != "home" {
+		t.Errorf("home: %+v", home)
+	}
+
+	if items == nil {
+		t.Fatal("/api/items missing")
+	} else if items.Path() != "/api/items" || strings.Join(items.Methods, ",") != "GET,HEAD,POST" {
+		t.Errorf("items: %q %v", items.Path(), items.Methods)
+	} else if len(items.Handlers) != 2 || items.Handlers[0].Middleware != 1 {
+		t.Errorf("items handlers: %+v", items.Handlers)
+	}
+
+	var b strings.Builder
+	if err := ri.WriteText(&b); err != nil {
+		t.Fatal(err)
+	} else if s := b.String(); !strings.HasPrefix(s, "PATTERN") || !strings.Contains(s, "/api/items") {
+		t.Errorf("unexpected text %q", s)
+	}
+}
+
+// brokenWriter fails like a disconnected client
+type brokenWriter struct {
+	*httptest.ResponseRecorder
+}
+
+func (brokenWriter) Write([]byte) (int, error) {
+	return 0, errors.New("broken pipe")
+}
+
+func TestDebugHandler(t *testing.T) {
+	m := NewRouter(nil).(*Mux)
+	m.HandleFunc("/items", func(w http.ResponseWriter, r *http.Request) {})
+
+	dh := m.DebugHandler()
+
+	req := httptest.NewRequest("GET", "/debug/routes", nil)
+	req.Header.Set("Accept", "application/json")
+	rec := httptest.NewRecorder()
+	dh.ServeHTTP(rec, req)
+
+	var ri RouteInfo
+	if err := json.Unmarshal(rec.Body.Bytes(), &ri); err != nil {
+		t.Fatal(err)
+	} else if len(ri.Routes) != 1 || ri.Routes[0].Pattern != "/items" {
+		t.Errorf("unexpected %+v", ri)
+	}
+
+	rec = httptest.NewRecorder()
+	dh.ServeHTTP(rec, httptest.NewRequest("GET", "/debug/routes", nil))
+	if s := rec.Header().Get("Content-Type"); !strings.HasPrefix(s, "text/plain") {
+		t.Errorf("Content-Type %q", s)
+	}
+
+	// no panic on write failures
+	dh.ServeHTTP(brokenWriter{httptest.NewRecorder()}, httptest.NewRequest("GET", "/", nil))
+}
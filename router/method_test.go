@@ -0,0 +1,57 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.sancus.dev/web"
+)
+
+func TestMethodMiddleware(t *testing.T) {
+	h := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	auth := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") == "" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+
+	m := NewRouter(nil).(*Mux)
+	m.With(auth).MethodFunc("POST", "/items", h)
+	m.MethodFunc("GET", "/items", h)
+	m.With(auth).MethodFunc("DELETE", "/items", h)
+
+	for _, tc := range []struct {
+		method string
+		code   int
+	}{
+		{"GET", 200},
+		{"HEAD", 200},
+		{"POST", 401},
+		{"DELETE", 401},
+	} {
+		rec := httptest.NewRecorder()
+		m.ServeHTTP(rec, httptest.NewRequest(tc.method, "/items", nil))
+
+		if rec.Code != tc.code {
+			t.Errorf("%s: %v instead of %v", tc.method, rec.Code, tc.code)
+		}
+	}
+
+	chains := make(map[string]int)
+	m.WalkMethods(func(pattern, method string, h interface{}, middleware []web.MiddlewareHandlerFunc) bool {
+		chains[method] = len(middleware)
+		return false
+	})
+
+	if chains["GET"] != 0 || chains["POST"] != 1 || chains["DELETE"] != 1 {
+		t.Errorf("bad middleware per method: %v", chains)
+	}
+}
@@ -0,0 +1,52 @@
+package router
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"go.sancus.dev/web/context"
+)
+
+// mount is a foreign http.Handler that only knows about req.URL.Path
+type mount struct {
+	h http.Handler
+}
+
+// ServeHTTP passes the rest of the path as URL.Path of a clone of the request.
+// RequestURI and the original request are left untouched for logging
+func (m *mount) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.h.ServeHTTP(w, stripPrefix(r))
+}
+
+func stripPrefix(r *http.Request) *http.Request {
+	rctx := context.RouteContext(r.Context())
+	if rctx == nil {
+		return r
+	}
+
+	path := rctx.RoutePath
+	if path == "" {
+		path = "/"
+	}
+
+	r2 := new(http.Request)
+	*r2 = *r
+	r2.URL = new(url.URL)
+	*r2.URL = *r.URL
+	r2.URL.Path = path
+	r2.URL.RawPath = ""
+
+	if raw := r.URL.RawPath; raw != "" {
+		// same decoded prefix, like http.StripPrefix
+		prefix := strings.TrimSuffix(r.URL.Path, rctx.RoutePath)
+		if s := strings.TrimPrefix(raw, prefix); s != raw {
+			if s == "" {
+				s = "/"
+			}
+			r2.URL.RawPath = s
+		}
+	}
+
+	return r2
+}
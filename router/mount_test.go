@@ -0,0 +1,44 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMount(t *testing.T) {
+	var paths []string
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "%s %s %s", r.URL.Path, r.URL.RawPath, r.RequestURI)
+	})
+
+	m := NewRouter(nil)
+	m.Mount("/static", h)
+	m.Route("/admin", func(r Router) {
+		r.Mount("/ui/", h)
+	})
+
+	for _, tc := range [][2]string{
+		{"/static/", "/  /static/"},
+		{"/static/css/a.css?v=1", "/css/a.css  /static/css/a.css?v=1"},
+		{"/admin/ui/a%2Fb", "/a/b /a%2Fb /admin/ui/a%2Fb"},
+	} {
+		rec := httptest.NewRecorder()
+		m.ServeHTTP(rec, httptest.NewRequest("GET", tc[0], nil))
+
+		if s := rec.Body.String(); s != tc[1] {
+			t.Errorf("%q: %q instead of %q", tc[0], s, tc[1])
+		}
+	}
+
+	m.Walk(func(pattern string, h Handler) bool {
+		paths = append(paths, pattern)
+		return false
+	})
+
+	if s := fmt.Sprint(paths); s != "[/admin/ui/* /static/*]" {
+		t.Errorf("walked %s", s)
+	}
+}
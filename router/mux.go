@@ -2,7 +2,6 @@ package router
 
 import (
 	"net/http"
-	"regexp"
 
 	"github.com/armon/go-radix"
 
@@ -16,8 +15,19 @@ type Mux struct {
 	node
 
 	trie         *radix.Tree
-	pattern      map[*regexp.Regexp]*node
+	segments     segNode
+	pattern      []*patternNode
+	names        map[string]*node
 	errorHandler web.ErrorHandlerFunc
+
+	// hooks, inherited from the parent unless set
+	parent           *Mux
+	policy           *PathPolicy
+	notFound         web.ErrorHandlerFunc
+	methodNotAllowed web.ErrorHandlerFunc
+
+	validation bool
+	problems   RouteErrors
 }
 
 func NewRouter(h web.ErrorHandlerFunc) Router {
@@ -27,7 +37,6 @@ func NewRouter(h web.ErrorHandlerFunc) Router {
 
 	m := &Mux{
 		trie:         radix.New(),
-		pattern:      make(map[*regexp.Regexp]*node),
 		errorHandler: h,
 	}
 
@@ -49,12 +58,12 @@ func (m *Mux) getNode(path string) *node {
 	}
 
 	if p, err := m.parsePath(path); err != nil {
-		panic(err)
+		return m.invalidNode(path, err)
 	} else if p.Literal() {
 		// reuse node when there is a match
 		path = p.Path()
-		if _, s1, h := m.findBestNode(path, nil); h != nil && len(s1) == 0 {
-			return h
+		if v, ok := m.trie.Get(path); ok {
+			return v.(*node)
 		}
 
 		// or create a new one
@@ -70,21 +79,18 @@ func (m *Mux) getNode(path string) *node {
 		pattern := p.Pattern()
 		for _, n := range m.pattern {
 			if n.Pattern == pattern {
-				return n
+				return n.node
 			}
 		}
 
 		// or create a new one
-		re, err := p.Compile()
-		if err != nil {
-			panic(err)
-		}
-
 		n := &node{
 			Pattern: pattern,
 		}
 		n.initRaw(m)
-		m.pattern[re] = n
+		if err := m.addPattern(n, p.Template()); err != nil {
+			return m.invalidNode(path, err)
+		}
 
 		return n
 	}
@@ -96,17 +102,76 @@ func (m *Mux) tryServeHTTP(w http.ResponseWriter, r *http.Request) error {
 	// get (or create) RoutingContext and the corresponding Route Path
 	ctx, rctx, path := context.GetRouteContextPath(r)
 
+	if err := m.tryClean(r); err != nil {
+		return err
+	}
+
 	if h, rctx, ok := m.Resolve(path, rctx); ok {
 
 		ctx = context.WithRouteContext(ctx, rctx)
 		r = r.WithContext(ctx)
 
-		return h.TryServeHTTP(w, r)
+		err := h.TryServeHTTP(w, r)
+		if err == errors.ErrNotFound {
+			// node without handler
+			return m.handleNotFound(w, r)
+		} else if e, ok := err.(*errors.MethodNotAllowedError); ok && e.Status() == http.StatusMethodNotAllowed {
+			if f := m.methodNotAllowedHandler(); f != nil {
+				f(w, r, e)
+				return nil
+			}
+		}
+		return err
+	}
+
+	r = r.WithContext(ctx)
+	if ok, err := m.trySlash(w, r, rctx); ok {
+		return err
 	}
 
+	return m.handleNotFound(w, r)
+}
+
+func (m *Mux) handleNotFound(w http.ResponseWriter, r *http.Request) error {
+	if f := m.notFoundHandler(); f != nil {
+		f(w, r, errors.ErrNotFound)
+		return nil
+	}
 	return errors.ErrNotFound
 }
 
+// NotFound sets the handler for requests this Router and its subrouters
+// can't resolve. It gets the RoutingContext as far as it got resolved
+func (m *Mux) NotFound(f web.ErrorHandlerFunc) Router {
+	m.notFound = f
+	return m
+}
+
+// MethodNotAllowed sets the handler for methods not handled by a route
+// of this Router or its subrouters. The error is a *errors.MethodNotAllowedError
+func (m *Mux) MethodNotAllowed(f web.ErrorHandlerFunc) Router {
+	m.methodNotAllowed = f
+	return m
+}
+
+func (m *Mux) notFoundHandler() web.ErrorHandlerFunc {
+	for ; m != nil; m = m.parent {
+		if m.notFound != nil {
+			return m.notFound
+		}
+	}
+	return nil
+}
+
+func (m *Mux) methodNotAllowedHandler() web.ErrorHandlerFunc {
+	for ; m != nil; m = m.parent {
+		if m.methodNotAllowed != nil {
+			return m.methodNotAllowed
+		}
+	}
+	return nil
+}
+
 // Use appends middleware to the entrypoint of the Router
 func (m *Mux) Use(f web.MiddlewareHandlerFunc) Router {
 	if v, ok := m.node.Handler.(interface {
@@ -120,6 +185,16 @@ func (m *Mux) Use(f web.MiddlewareHandlerFunc) Router {
 	return m
 }
 
+// When restricts the routes registered through the returned MiniRouter
+// to requests matching all the given predicates. Requests matching
+// the path but none of the alternatives get a 404, or 406/415 if
+// only media types failed
+func (m *Mux) When(preds ...Predicate) MiniRouter {
+	chain := &Chain{}
+	chain.init(m)
+	return chain.When(preds...)
+}
+
 func (m *Mux) With(f web.MiddlewareHandlerFunc) MiniRouter {
 	chain := &Chain{}
 	chain.init(m)
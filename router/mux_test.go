@@ -0,0 +1,73 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.sancus.dev/web/context"
+	"go.sancus.dev/web/errors"
+)
+
+func TestNotFoundHooks(t *testing.T) {
+	h := func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "ok")
+	}
+
+	hook := func(name string) func(http.ResponseWriter, *http.Request, error) {
+		return func(w http.ResponseWriter, r *http.Request, err error) {
+			var code int
+
+			prefix := context.RouteContext(r.Context()).RoutePrefix
+			if e, ok := err.(*errors.MethodNotAllowedError); ok {
+				code = e.Status()
+				w.Header().Set("Allow", fmt.Sprint(e.Allowed))
+			} else if err == errors.ErrNotFound {
+				code = http.StatusNotFound
+			}
+
+			w.WriteHeader(code)
+			fmt.Fprintf(w, "%s %s", name, prefix)
+		}
+	}
+
+	m := NewRouter(nil)
+	m.NotFound(hook("html"))
+	m.HandleFunc("/", h)
+	m.Route("/api", func(r Router) {
+		r.NotFound(hook("json"))
+		r.MethodNotAllowed(hook("json"))
+		r.MethodFunc("GET", "/items", h)
+		r.Route("/v1", func(r Router) {
+			r.MethodFunc("GET", "/items", h)
+		})
+	})
+	m.Route("/docs", func(r Router) {
+		r.MethodFunc("GET", "/", h)
+	})
+
+	for _, tc := range []struct {
+		method, path string
+		code         int
+		body         string
+	}{
+		{"GET", "/missing", 404, "html /"},
+		{"GET", "/docs/missing", 404, "html /docs"},
+		{"POST", "/docs/", 405, ""},
+		{"GET", "/api/missing", 404, "json /api"},
+		{"GET", "/api/v1/missing", 404, "json /api/v1"},
+		{"POST", "/api/items", 405, "json /api/items"},
+		{"DELETE", "/api/v1/items", 405, "json /api/v1/items"},
+		{"GET", "/api/v1/items", 200, "ok"},
+	} {
+		rec := httptest.NewRecorder()
+		m.ServeHTTP(rec, httptest.NewRequest(tc.method, tc.path, nil))
+
+		if rec.Code != tc.code {
+			t.Errorf("%s %s: %v instead of %v", tc.method, tc.path, rec.Code, tc.code)
+		} else if tc.body != "" && rec.Body.String() != tc.body {
+			t.Errorf("%s %s: %q instead of %q", tc.method, tc.path, rec.Body.String(), tc.body)
+		}
+	}
+}
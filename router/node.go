@@ -2,6 +2,7 @@ package router
 
 import (
 	"net/http"
+	"strings"
 
 	"go.sancus.dev/web"
 	"go.sancus.dev/web/intercept"
@@ -40,15 +41,20 @@ func (n *entry) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 func (n *entry) use(f web.MiddlewareHandlerFunc) {
 	if f != nil {
 		n.chain = append(n.chain, f)
+		n.node.middleware = append(n.node.middleware, f)
 	}
 }
 
 // a rawNode is a node that hasn't been compiled into a final Handler yet
 type rawNode struct {
-	h     web.Handler
-	node  *node
-	mux   *Mux
-	chain []web.MiddlewareHandlerFunc
+	h      web.Handler
+	node   *node
+	mux    *Mux
+	chain  []web.MiddlewareHandlerFunc
+	groups []string // names of the Groups of the chain
+
+	preds      []Predicate
+	predicates map[string]*predicateHandler
 }
 
 func (n *node) initRaw(mux *Mux) {
@@ -73,38 +79,125 @@ func (n *rawNode) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 }
 
 func (n *rawNode) handle(h2 http.Handler) {
-	n.tryHandle(n.asHandler(h2))
+	n.setHandler(n.asHandler(h2), h2)
 }
 
 func (n *rawNode) method(method string, h http.Handler) {
-	n.tryMethod(method, n.asHandler(h))
+	n.setMethod(method, n.asHandler(h), h)
+}
+
+func (n *rawNode) mount(h2 http.Handler) {
+	if h2 != nil {
+		n.setHandler(n.asHandler(&mount{h2}), h2)
+	}
 }
 
 func (n *rawNode) tryHandle(h web.Handler) {
+	n.setHandler(h, h)
+}
+
+func (n *rawNode) tryMethod(method string, h web.Handler) {
+	n.setMethod(method, h, h)
+}
+
+func (n *rawNode) setHandler(h web.Handler, orig interface{}) {
+	n.register("*", orig)
+	h, chain := n.predicated("*", h, n.consume())
+
 	if v, ok := n.h.(*MethodHandler); ok {
-		v.set("*", h, n.chain...)
+		v.set("*", h, chain...)
 	} else {
-		n.h = NewHandler(h, n.chain, nil)
+		n.h = NewHandler(h, chain, nil)
 	}
 }
 
-func (n *rawNode) tryMethod(method string, h web.Handler) {
+func (n *rawNode) setMethod(method string, h web.Handler, orig interface{}) {
+	method = strings.ToUpper(method)
+
+	n.register(method, orig)
+	h, chain := n.predicated(method, h, n.consume())
+
 	v, ok := n.h.(*MethodHandler)
 	if !ok {
 		v = NewMethodHandler(n.h)
 		n.h = v
 	}
 
-	v.set(method, h, n.chain...)
+	v.set(method, h, chain...)
+
+	if method == "GET" && !n.registered("HEAD") {
+		// implicit HEAD follows GET
+		v.handler["HEAD"] = v.handler["GET"]
+	}
 }
 
+// registered tells if a handler was explicitly given for a method
+func (n *rawNode) registered(method string) bool {
+	for _, v := range n.node.handlers {
+		if v.method == method {
+			return true
+		}
+	}
+	return false
+}
+
+// register keeps track of the handlers given to the node
+func (n *rawNode) register(method string, h interface{}) {
+	v := handlerInfo{
+		method:     strings.ToUpper(method),
+		handler:    h,
+		typ:        typeName(h),
+		middleware: n.chain,
+		groups:     n.groups,
+	}
+
+	if _, ok := n.predicates[v.method]; ok || len(n.preds) > 0 {
+		// alternatives, picked by predicates
+		n.node.handlers = append(n.node.handlers, v)
+		return
+	}
+
+	for i, v2 := range n.node.handlers {
+		if v2.method == v.method {
+			n.mux.duplicate(n.node.Pattern, v.method)
+			n.node.handlers[i] = v
+			return
+		}
+	}
+
+	n.node.handlers = append(n.node.handlers, v)
+}
+
+// with sets the middleware for the next registration on the node
 func (n *rawNode) with(chain ...web.MiddlewareHandlerFunc) {
 	n.chain = chain
 }
 
+// group sets the names of the Groups for the next registration
+func (n *rawNode) group(names ...string) {
+	n.groups = names
+}
+
+// consume returns the middleware set by with(), so it only applies
+// to the method being registered
+func (n *rawNode) consume() []web.MiddlewareHandlerFunc {
+	chain := n.chain
+	n.chain = nil
+	n.groups = nil
+	return chain
+}
+
 func (n *rawNode) route(fn func(Router)) Router {
+	n.preds = nil // subrouters have their own
+
 	r := NewRouter(n.mux.errorHandler)
-	for _, f := range n.chain {
+	r.(*Mux).parent = n.mux
+	r.(*Mux).node.groups = n.groups
+	if n.mux.validation {
+		r.(*Mux).WithValidation()
+	}
+
+	for _, f := range n.consume() {
 		r.Use(f)
 	}
 
@@ -0,0 +1,270 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go.sancus.dev/web/openapi"
+	"go.sancus.dev/web/pathparser"
+)
+
+// OpenAPI generates an OpenAPI document describing the routes
+// of the Mux and its subrouters. Handlers can contribute to their
+// operations by implementing
+// `Describe(method string, op *openapi.Operation)`, and those
+// registered for any method can tell which by implementing
+// `Methods() []string`. Subtree handlers (foo/*), including the ones
+// given to Mount(), are only included if they implement OpenAPIDescriber,
+// like Mux and VersionMux do.
+func (m *Mux) OpenAPI(info openapi.Info) *openapi.Document {
+	doc := &openapi.Document{
+		OpenAPI: openapi.Version,
+		Info:    info,
+		Paths:   make(map[string]*openapi.PathItem),
+	}
+
+	m.openAPI(doc, "")
+	return doc
+}
+
+func (m *Mux) openAPI(doc *openapi.Document, prefix string) {
+	m.eachNode(func(n *node) bool {
+		pattern := prefix + n.Pattern
+
+		if sub := n.router(); sub != nil {
+			sub.openAPI(doc, strings.TrimSuffix(pattern, "/*"))
+		} else if strings.HasSuffix(pattern, "/*") {
+			// subtrees, if they can tell
+			for _, v := range n.handlers {
+				if h, ok := v.handler.(OpenAPIDescriber); ok {
+					h.DescribeOpenAPI(doc, strings.TrimSuffix(pattern, "/*"))
+				}
+			}
+		} else if len(n.handlers) > 0 {
+			for _, item := range openAPIPaths(pattern) {
+				if p, ok := doc.Paths[item.path]; ok {
+					// another alternative of the same pattern
+					item.PathItem = p
+				} else {
+					doc.Paths[item.path] = item.PathItem
+				}
+
+				n.openAPIOperations(item.PathItem)
+			}
+		}
+		return false
+	})
+}
+
+// OpenAPIDescriber is implemented by subtree handlers able to add
+// their routes to an OpenAPI document, under the given prefix
+type OpenAPIDescriber interface {
+	DescribeOpenAPI(doc *openapi.Document, prefix string)
+}
+
+// DescribeOpenAPI adds the routes of the Mux, as mounted on prefix
+func (m *Mux) DescribeOpenAPI(doc *openapi.Document, prefix string) {
+	m.openAPI(doc, strings.TrimSuffix(prefix, "/"))
+}
+
+// DescribeOpenAPI adds the routes of the versions. If the version
+// is chosen by path each goes under its name, as Walk() lists them.
+// Otherwise only the default version is described: the others are
+// served on the same paths, which a document can't tell apart, and
+// the /v1/ prefixes Walk() uses for inspection don't exist to clients
+func (vm *VersionMux) DescribeOpenAPI(doc *openapi.Document, prefix string) {
+	prefix = strings.TrimSuffix(prefix, "/")
+
+	if !vm.Path {
+		if v := vm.fallback(); v != nil {
+			v.describeOpenAPI(doc, prefix)
+		}
+		return
+	}
+
+	for _, v := range vm.versions {
+		v.describeOpenAPI(doc, prefix+"/"+v.Name)
+	}
+}
+
+func (v *version) describeOpenAPI(doc *openapi.Document, prefix string) {
+	tmp := &openapi.Document{
+		Paths: make(map[string]*openapi.PathItem),
+	}
+
+	v.router.openAPI(tmp, prefix)
+
+	for path, item := range tmp.Paths {
+		if !v.Deprecated.IsZero() {
+			for _, method := range openAPIMethods {
+				if p := item.Operation(method); *p != nil {
+					(*p).Deprecated = true
+				}
+			}
+		}
+		doc.Paths[path] = item
+	}
+}
+
+var openAPIMethods = []string{"GET", "PUT", "POST", "DELETE", "OPTIONS", "HEAD", "PATCH", "TRACE"}
+
+type openAPIPath struct {
+	*openapi.PathItem
+	path string
+}
+
+// openAPIPaths renders a pattern into OpenAPI paths, one for each
+// combination of optional groups
+func openAPIPaths(pattern string) []openAPIPath {
+	var out []openAPIPath
+
+	if pattern == "/" {
+		// special case, the parser wants segments
+		return []openAPIPath{{&openapi.PathItem{}, pattern}}
+	}
+
+	p, err := pathparser.Parse(pattern)
+	if err != nil {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+
+	for _, alt := range expandOptionals(p.Template()) {
+		var b strings.Builder
+		var stars int
+
+		item := &openapi.PathItem{}
+
+		for _, v := range alt {
+			var name string
+
+			switch v.Type {
+			case pathparser.PartLiteral:
+				b.WriteString(v.Value)
+				continue
+			case pathparser.PartStar:
+				if stars++; stars > 1 {
+					name = fmt.Sprintf("wildcard%v", stars)
+				} else {
+					name = "wildcard"
+				}
+			default:
+				name = v.Value
+			}
+
+			fmt.Fprintf(&b, "{%s}", name)
+			item.Parameters = append(item.Parameters, &openapi.Parameter{
+				Name:     name,
+				In:       "path",
+				Required: true,
+				Schema:   openAPISchema(v),
+			})
+		}
+
+		s := b.String()
+		if k := strings.TrimSuffix(s, "/"); seen[k] {
+			// optional group reduced to its slash
+			continue
+		} else {
+			seen[k] = true
+		}
+
+		out = append(out, openAPIPath{
+			PathItem: item,
+			path:     s,
+		})
+	}
+
+	return out
+}
+
+// openAPISchema describes the values a capture accepts
+func openAPISchema(v pathparser.Part) *openapi.Schema {
+	var zero float64
+
+	s := &openapi.Schema{
+		Type: "string",
+	}
+
+	switch {
+	case v.Type == pathparser.PartCatchAll:
+		s.Description = "rest of the path, slashes included"
+	case v.Constraint == "int":
+		s.Type = "integer"
+	case v.Constraint == "uint":
+		s.Type = "integer"
+		s.Minimum = &zero
+	case v.Constraint == "uuid", v.Constraint == "date":
+		s.Format = v.Constraint
+	case v.Constraint != "":
+		if c, ok := pathparser.GetConstraint(v.Constraint); ok {
+			s.Pattern = "^(?:" + c.Expr() + ")$"
+		}
+	case len(v.Options) > 0:
+		for _, o := range v.Options {
+			if regexp.QuoteMeta(o) != o {
+				// not literals
+				s.Enum = nil
+				s.Pattern = "^(" + strings.Join(v.Options, "|") + ")$"
+				break
+			}
+			s.Enum = append(s.Enum, o)
+		}
+	}
+
+	return s
+}
+
+// openAPIOperations adds the operations of the node to a PathItem
+func (n *node) openAPIOperations(item *openapi.PathItem) {
+	handlers := make(map[string]interface{})
+
+	for _, v := range n.handlers {
+		if v.method != "*" {
+			handlers[v.method] = v.handler
+		} else if h, ok := v.handler.(interface {
+			Methods() []string
+		}); ok {
+			for _, method := range h.Methods() {
+				if _, ok := handlers[method]; !ok {
+					handlers[method] = v.handler
+				}
+			}
+		} else if _, ok := handlers["GET"]; !ok {
+			handlers["GET"] = v.handler
+		}
+	}
+
+	for method, h := range handlers {
+		if _, ok := handlers["GET"]; method == "OPTIONS" || (ok && method == "HEAD") {
+			// implied
+			continue
+		}
+
+		if p := item.Operation(method); p != nil {
+			op := &openapi.Operation{
+				Responses: map[string]*openapi.Response{
+					strconv.Itoa(http.StatusOK): {
+						Description: http.StatusText(http.StatusOK),
+					},
+				},
+			}
+
+			if n.Name != "" {
+				op.OperationID = n.Name + "." + strings.ToLower(method)
+			}
+
+			if h, ok := h.(interface {
+				Describe(string, *openapi.Operation)
+			}); ok {
+				h.Describe(method, op)
+			}
+
+			*p = op
+		}
+	}
+}
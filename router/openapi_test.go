@@ -0,0 +1,98 @@
+package router
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.sancus.dev/web/openapi"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func TestOpenAPIVersionHeader(t *testing.T) {
+	h := func(w http.ResponseWriter, r *http.Request) {}
+
+	m := NewRouter(nil).(*Mux)
+	vm := m.Versions("/api", VersionOptions{Header: "X-API-Version", Default: "v1"})
+	vm.Version(Version{Name: "v1"}, func(r Router) {
+		r.MethodFunc("GET", "/old", h)
+	})
+	vm.Version(Version{Name: "v2"}, func(r Router) {
+		r.MethodFunc("GET", "/new", h)
+	})
+
+	doc := m.OpenAPI(openapi.Info{Title: "test", Version: "1.0"})
+
+	var paths []string
+	for path := range doc.Paths {
+		paths = append(paths, path)
+	}
+
+	if len(paths) != 1 || paths[0] != "/api/old" {
+		t.Errorf("%q instead of the default version", paths)
+	}
+}
+
+type describedHandler struct{}
+
+func (describedHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {}
+
+func (describedHandler) Methods() []string {
+	return []string{"GET", "PUT"}
+}
+
+func (describedHandler) Describe(method string, op *openapi.Operation) {
+	op.Summary = method + " an item"
+}
+
+func TestOpenAPI(t *testing.T) {
+	h := func(w http.ResponseWriter, r *http.Request) {}
+
+	files := NewRouter(nil)
+	files.HandleFunc("/{path...}", h)
+
+	m := NewRouter(nil).(*Mux)
+	m.HandleFunc("/", h)
+	m.Named("item").Handle("/items/{id<int>}", describedHandler{})
+	m.Route("/users/{user}", func(r Router) {
+		r.MethodFunc("POST", "/posts/{d<date>}", h)
+	})
+	m.Mount("/files", files)
+	m.HandleFunc("/static/*", h) // opaque, not included
+
+	vm := m.Versions("/api", VersionOptions{Path: true})
+	vm.Version(Version{Name: "v1", Deprecated: time.Unix(0, 0)}, func(r Router) {
+		r.MethodFunc("GET", "/status", h)
+	})
+	vm.Version(Version{Name: "v2"}, func(r Router) {
+		r.MethodFunc("GET", "/status", h)
+	})
+
+	b, err := json.MarshalIndent(m.OpenAPI(openapi.Info{
+		Title:   "test",
+		Version: "1.0",
+	}), "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	golden := filepath.Join("testdata", "openapi.json")
+	if *update {
+		if err := ioutil.WriteFile(golden, append(b, '\n'), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, err := ioutil.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	} else if !bytes.Equal(append(b, '\n'), want) {
+		t.Errorf("%s differs, got:\n%s", golden, b)
+	}
+}
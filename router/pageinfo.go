@@ -0,0 +1,234 @@
+package router
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"go.sancus.dev/web"
+	"go.sancus.dev/web/context"
+)
+
+// PageInfo describes the route a request resolves to, and passes
+// through the PageInfo interfaces of its handler. Values the handler
+// doesn't provide are left empty.
+type PageInfo struct {
+	Pattern string // including the prefix of the subrouters
+	Path    string
+	Name    string
+	Params  map[string]interface{}
+	Methods []string // "*" for any
+
+	handler interface{} // as registered
+	info    interface{} // PageInfo of the handler
+	node    *node
+	rctx    *context.RoutingContext
+}
+
+// PageInfo resolves a request to its route without invoking the handler.
+// Handlers implementing web.RouterPageInfo are asked if the page exists.
+func (m *Mux) PageInfo(r *http.Request) (interface{}, bool) {
+	_, rctx, _ := context.GetRouteContextPath(r)
+
+	if p := m.pageInfo(r, rctx, ""); p != nil {
+		return p, true
+	}
+	return nil, false
+}
+
+// Lookup resolves a path to its route through the subrouters, without
+// asking the handler if the page exists
+func (m *Mux) Lookup(path string) (*PageInfo, bool) {
+	rctx := context.NewRouteContext("", path)
+
+	if p := m.lookup(path, rctx, ""); p != nil {
+		return p, true
+	}
+	return nil, false
+}
+
+func (m *Mux) lookup(path string, rctx *context.RoutingContext, prefix string) *PageInfo {
+	h, rctx, ok := m.Resolve(rctx.RoutePath, rctx)
+	if !ok {
+		return nil
+	}
+
+	n, ok := h.(*node)
+	if !ok {
+		// only a redirect lives here
+		return nil
+	}
+
+	if sub := n.router(); sub != nil {
+		return sub.lookup(path, rctx, prefix+strings.TrimSuffix(n.Pattern, "/*"))
+	} else if len(n.handlers) == 0 {
+		return nil
+	}
+
+	p := &PageInfo{
+		Pattern: prefix + n.Pattern,
+		Path:    path,
+		Name:    n.Name,
+		Params:  rctx.RouteParams,
+		Methods: n.methods(),
+		node:    n,
+		rctx:    rctx,
+	}
+
+	p.handler = n.handler("GET")
+	p.info = p.handler
+	return p
+}
+
+func (m *Mux) pageInfo(r *http.Request, rctx *context.RoutingContext, prefix string) *PageInfo {
+	p := m.lookup(r.URL.Path, rctx, prefix)
+	if p == nil {
+		return nil
+	}
+
+	if v, ok := p.handler.(web.RouterPageInfo); ok {
+		r = r.WithContext(context.WithRouteContext(r.Context(), p.rctx))
+
+		info, ok := v.PageInfo(r)
+		if !ok {
+			return nil
+		}
+		p.info = info
+	}
+
+	return p
+}
+
+// PageInfo resolves the host of the request and then the path
+// on the corresponding router
+func (m *HostMux) PageInfo(r *http.Request) (interface{}, bool) {
+	args := make(map[string]interface{})
+
+	h, ok := m.Resolve(r.Host, args)
+	if !ok {
+		return nil, false
+	}
+
+	if len(args) > 0 {
+		ctx, rctx, _ := context.GetRouteContextPath(r)
+
+		rctx = rctx.Clone()
+		for k, v := range args {
+			rctx.Set(k, v)
+		}
+
+		r = r.WithContext(context.WithRouteContext(ctx, rctx))
+	}
+
+	if v, ok := h.(web.RouterPageInfo); ok {
+		return v.PageInfo(r)
+	}
+	return nil, false
+}
+
+// handler returns the handler registered for a method, or for any
+func (n *node) handler(method string) interface{} {
+	var any interface{}
+
+	for _, v := range n.handlers {
+		switch v.method {
+		case method:
+			return v.handler
+		case "*":
+			any = v.handler
+		}
+	}
+	return any
+}
+
+// methods returns the methods a leaf node handles
+func (n *node) methods() []string {
+	if h := n.methodHandler(); h != nil {
+		return h.methods()
+	} else if len(n.handlers) > 0 {
+		return []string{"*"}
+	}
+	return nil
+}
+
+// Info returns the PageInfo provided by the handler, or the
+// handler itself
+func (p *PageInfo) Info() interface{} {
+	return p.info
+}
+
+// Location returns the path requested
+func (p *PageInfo) Location() string {
+	if v, ok := p.info.(web.PageInfoLocation); ok {
+		if s := v.Location(); s != "" {
+			return s
+		}
+	}
+	return p.Path
+}
+
+func (p *PageInfo) Canonical() string {
+	if v, ok := p.info.(web.PageInfoCanonical); ok {
+		return v.Canonical()
+	}
+	return ""
+}
+
+func (p *PageInfo) ChangeFrequency() string {
+	if v, ok := p.info.(web.PageInfoChangeFrequency); ok {
+		return v.ChangeFrequency()
+	}
+	return ""
+}
+
+func (p *PageInfo) Priority() float32 {
+	if v, ok := p.info.(web.PageInfoPriority); ok {
+		return v.Priority()
+	}
+	return 0
+}
+
+func (p *PageInfo) LastModified() time.Time {
+	if v, ok := p.info.(web.PageInfoLastModified); ok {
+		return v.LastModified()
+	}
+	return time.Time{}
+}
+
+func (p *PageInfo) MimeType() []string {
+	if v, ok := p.info.(web.PageInfoMimeType); ok {
+		return v.MimeType()
+	}
+	return nil
+}
+
+func (p *PageInfo) Language() []string {
+	if v, ok := p.info.(web.PageInfoLanguage); ok {
+		return v.Language()
+	}
+	return nil
+}
+
+// Method returns the methods allowed, as told by the handler or
+// as registered
+func (p *PageInfo) Method() []string {
+	if v, ok := p.info.(web.PageInfoMethods); ok {
+		return v.Method()
+	}
+	return p.Methods
+}
+
+// Handler returns the handler serving the page, with the routing
+// context already resolved. Middleware Use()d by the routers is skipped
+func (p *PageInfo) Handler() http.Handler {
+	if v, ok := p.info.(web.PageInfoHandler); ok {
+		if h := v.Handler(); h != nil {
+			return h
+		}
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithRouteContext(r.Context(), p.rctx)
+		p.node.Handler.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -0,0 +1,83 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+type pageInfoHandler struct {
+	exists bool
+}
+
+func (h pageInfoHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {}
+
+func (h pageInfoHandler) PageInfo(r *http.Request) (interface{}, bool) {
+	return h, h.exists
+}
+
+func (h pageInfoHandler) Priority() float32 {
+	return 0.7
+}
+
+func TestPageInfo(t *testing.T) {
+	var called bool
+
+	h := func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}
+
+	m := NewRouter(nil).(*Mux)
+	m.Named("item").MethodFunc("GET", "/items/{id<int>}", h)
+	m.MethodFunc("POST", "/items/{id<int>}", h)
+	m.Route("/docs", func(r Router) {
+		r.Handle("/", pageInfoHandler{true})
+		r.Handle("/{path...}", pageInfoHandler{false})
+	})
+
+	for _, tc := range []struct {
+		path    string
+		pattern string
+		params  map[string]interface{}
+		methods []string
+	}{
+		{"/items/3", "/items/{id<int>}", map[string]interface{}{"id": 3}, []string{"GET", "HEAD", "POST"}},
+		{"/docs/", "/docs/", nil, []string{"*"}},
+		{"/items/x", "", nil, nil},
+		{"/docs/missing", "", nil, nil},
+	} {
+		v, ok := m.PageInfo(httptest.NewRequest("GET", tc.path, nil))
+		if tc.pattern == "" {
+			if ok {
+				t.Errorf("%q: unexpected %#v", tc.path, v)
+			}
+			continue
+		} else if !ok {
+			t.Errorf("%q: not found", tc.path)
+			continue
+		}
+
+		p := v.(*PageInfo)
+		if p.Pattern != tc.pattern || p.Location() != tc.path {
+			t.Errorf("%q: resolved to %q (%q)", tc.path, p.Pattern, p.Location())
+		}
+
+		if len(tc.params) > 0 && !reflect.DeepEqual(p.Params, tc.params) {
+			t.Errorf("%q: params %v instead of %v", tc.path, p.Params, tc.params)
+		}
+
+		if !reflect.DeepEqual(p.Method(), tc.methods) {
+			t.Errorf("%q: methods %q instead of %q", tc.path, p.Method(), tc.methods)
+		}
+	}
+
+	if called {
+		t.Error("handler called")
+	}
+
+	v, _ := m.PageInfo(httptest.NewRequest("GET", "/docs/", nil))
+	if p := v.(*PageInfo).Priority(); p != 0.7 {
+		t.Errorf("priority %v not passed through", p)
+	}
+}
@@ -8,9 +8,10 @@ import (
 )
 
 type parser struct {
-	path    string
-	pattern string
-	re      string
+	path     string
+	pattern  string
+	re       string
+	template pathparser.Template
 }
 
 func (p *parser) Path() string {
@@ -21,6 +22,10 @@ func (p *parser) Pattern() string {
 	return p.pattern
 }
 
+func (p *parser) Template() pathparser.Template {
+	return p.template
+}
+
 func (p *parser) Literal() bool {
 	return len(p.re) == 0
 }
@@ -59,9 +64,13 @@ func (mux *Mux) parsePath(path string) (*parser, error) {
 		peg.Init()
 
 		if err := peg.Parse(); err != nil {
-			return nil, err
+			return nil, peg.SyntaxError(err)
 		}
 		peg.Execute()
+		if err := peg.Err(); err != nil {
+			return nil, err
+		}
+		p.template = peg.Template()
 
 		if !peg.Literal() {
 			p.re, _ = peg.Result()
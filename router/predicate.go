@@ -0,0 +1,297 @@
+package router
+
+import (
+	"mime"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"go.sancus.dev/web"
+	"go.sancus.dev/web/errors"
+	"go.sancus.dev/web/mimeparse"
+)
+
+// Predicate tells if a request, already matched by path, can be
+// handled by a route registered with When()
+type Predicate interface {
+	Match(*http.Request) bool
+}
+
+// PredicateFunc is a function usable as Predicate
+type PredicateFunc func(*http.Request) bool
+
+func (f PredicateFunc) Match(r *http.Request) bool {
+	return f(r)
+}
+
+// statusPredicate is a predicate with a better status than 404
+// to report when it fails
+type statusPredicate struct {
+	Predicate
+	status int
+	accept []string // media types wanted, for 415
+}
+
+// predicateStatus returns the status to report when a predicate fails
+func predicateStatus(p Predicate) (int, []string) {
+	if v, ok := p.(statusPredicate); ok {
+		return v.status, v.accept
+	}
+	return http.StatusNotFound, nil
+}
+
+// Header matches requests with a given header value
+func Header(name, value string) Predicate {
+	return PredicateFunc(func(r *http.Request) bool {
+		for _, s := range r.Header.Values(name) {
+			if s == value {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// HeaderRegexp matches requests with a header value matching
+// the given regular expression
+func HeaderRegexp(name, expr string) Predicate {
+	re := regexp.MustCompile(expr)
+
+	return PredicateFunc(func(r *http.Request) bool {
+		for _, s := range r.Header.Values(name) {
+			if re.MatchString(s) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// Query matches requests with a given query parameter, empty or not
+func Query(name string) Predicate {
+	return PredicateFunc(func(r *http.Request) bool {
+		_, ok := r.URL.Query()[name]
+		return ok
+	})
+}
+
+// QueryValue matches requests with a given query parameter value
+func QueryValue(name, value string) Predicate {
+	return PredicateFunc(func(r *http.Request) bool {
+		for _, s := range r.URL.Query()[name] {
+			if s == value {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// ContentType matches requests with a body of one of the given
+// media types, failing with 415
+func ContentType(mimetypes ...string) Predicate {
+	f := func(r *http.Request) bool {
+		s, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if err != nil {
+			return false
+		}
+
+		for _, v := range mimetypes {
+			if strings.EqualFold(s, v) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return statusPredicate{PredicateFunc(f), http.StatusUnsupportedMediaType, mimetypes}
+}
+
+// Accept matches requests accepting one of the given media types,
+// failing with 406. Requests without Accept header accept anything
+func Accept(mimetypes ...string) Predicate {
+	f := func(r *http.Request) bool {
+		if s := r.Header.Get("Accept"); s != "" {
+			return mimeparse.BestMatch(mimetypes, s) != ""
+		}
+		return true
+	}
+
+	return statusPredicate{PredicateFunc(f), http.StatusNotAcceptable, nil}
+}
+
+// Any matches requests matched by at least one of the predicates.
+// If all of them fail with the same status, so does Any
+func Any(preds ...Predicate) Predicate {
+	var status int
+	var accept []string
+
+	f := func(r *http.Request) bool {
+		for _, p := range preds {
+			if p.Match(r) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for i, p := range preds {
+		s, v := predicateStatus(p)
+		if i == 0 {
+			status = s
+		} else if s != status {
+			status = http.StatusNotFound
+		}
+		accept = append(accept, v...)
+	}
+
+	if status == 0 || status == http.StatusNotFound {
+		return PredicateFunc(f)
+	}
+	return statusPredicate{PredicateFunc(f), status, accept}
+}
+
+// Not matches requests not matched by the predicate, failing
+// with the same status
+func Not(p Predicate) Predicate {
+	f := func(r *http.Request) bool {
+		return !p.Match(r)
+	}
+
+	if status, _ := predicateStatus(p); status != http.StatusNotFound {
+		return statusPredicate{PredicateFunc(f), status, nil}
+	}
+	return PredicateFunc(f)
+}
+
+// predicateHandler picks the first candidate whose predicates
+// all match the request
+type predicateHandler struct {
+	candidates []predicateCandidate
+	fallback   web.Handler
+}
+
+type predicateCandidate struct {
+	preds []Predicate
+	h     web.Handler
+}
+
+func (p *predicateHandler) add(preds []Predicate, h web.Handler) {
+	if len(preds) == 0 {
+		p.fallback = h
+	} else {
+		p.candidates = append(p.candidates, predicateCandidate{preds, h})
+	}
+}
+
+func (p *predicateHandler) TryServeHTTP(w http.ResponseWriter, r *http.Request) error {
+	var status int
+	var accept []string
+
+	for _, c := range p.candidates {
+		s, v := c.match(r)
+		if s == 0 {
+			return c.h.TryServeHTTP(w, r)
+		} else if status == 0 {
+			status = s
+		} else if s != status {
+			status = http.StatusNotFound
+		}
+		accept = append(accept, v...)
+	}
+
+	if p.fallback != nil {
+		return p.fallback.TryServeHTTP(w, r)
+	}
+
+	switch status {
+	case http.StatusNotAcceptable:
+		return errors.ErrNotAcceptable
+	case http.StatusUnsupportedMediaType:
+		return unsupportedMediaType(r, accept)
+	default:
+		return errors.ErrNotFound
+	}
+}
+
+// match returns 0 if all predicates match, or the status
+// corresponding to the first one failing
+func (c predicateCandidate) match(r *http.Request) (int, []string) {
+	for _, p := range c.preds {
+		if !p.Match(r) {
+			return predicateStatus(p)
+		}
+	}
+	return 0, nil
+}
+
+// unsupportedMediaType tells the client what it could have sent
+func unsupportedMediaType(r *http.Request, accept []string) error {
+	var s []string
+
+	seen := make(map[string]bool, len(accept))
+	for _, v := range accept {
+		if k := strings.ToLower(v); !seen[k] {
+			seen[k] = true
+			s = append(s, v)
+		}
+	}
+
+	hdr := make(http.Header)
+	if len(s) > 0 {
+		hdr.Set("Accept", strings.Join(s, ", "))
+		if r.Method == "POST" {
+			hdr.Set("Accept-Post", strings.Join(s, ", "))
+		}
+	}
+
+	return &errors.HandlerError{
+		Code:   http.StatusUnsupportedMediaType,
+		Err:    errors.New("%q: unsupported media type", r.Header.Get("Content-Type")),
+		Header: hdr,
+	}
+}
+
+// predicated adds a handler to the predicateHandler of a method
+// if When() is involved, returning what the MethodHandler gets and
+// with what middleware
+func (n *rawNode) predicated(method string, h web.Handler, chain []web.MiddlewareHandlerFunc) (web.Handler, []web.MiddlewareHandlerFunc) {
+	preds := n.preds
+	n.preds = nil
+
+	p, ok := n.predicates[method]
+	if !ok {
+		if len(preds) == 0 {
+			return h, chain
+		}
+
+		p = &predicateHandler{}
+		if h0 := n.current(method); h0 != nil {
+			// registered before When(), serve what doesn't match
+			p.fallback = h0
+		}
+
+		if n.predicates == nil {
+			n.predicates = make(map[string]*predicateHandler, 1)
+		}
+		n.predicates[method] = p
+	}
+
+	p.add(preds, NewHandler(h, chain, nil))
+	return p, nil
+}
+
+// current returns the handler serving a method so far
+func (n *rawNode) current(method string) web.Handler {
+	if v, ok := n.h.(*MethodHandler); ok {
+		if h, ok := v.handler[method]; ok {
+			return h
+		}
+		return v.handler["*"]
+	}
+	return n.h
+}
+
+func (n *rawNode) when(preds ...Predicate) {
+	n.preds = preds
+}
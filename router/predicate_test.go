@@ -0,0 +1,141 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPredicates(t *testing.T) {
+	h := func(s string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, s)
+		}
+	}
+
+	m := NewRouter(nil)
+	m.When(Header("X-API-Version", "2")).HandleFunc("/items", h("v2"))
+	m.When(QueryValue("format", "csv")).HandleFunc("/items", h("csv"))
+	m.When(Accept("application/json")).HandleFunc("/items", h("json"))
+	m.HandleFunc("/items", h("html"))
+
+	m.When(Accept("application/json")).MethodFunc("GET", "/only", h("json"))
+	m.When(ContentType("application/json")).MethodFunc("POST", "/only", h("post"))
+	m.When(Any(Query("a"), HeaderRegexp("X-Tag", "^b+$"))).HandleFunc("/any", h("any"))
+
+	for _, tc := range []struct {
+		method, path string
+		header       [2]string
+		code         int
+		body         string
+	}{
+		{"GET", "/items", [2]string{"Accept", "text/html"}, 200, "html"},
+		{"GET", "/items", [2]string{"X-API-Version", "2"}, 200, "v2"},
+		{"GET", "/items?format=csv", [2]string{}, 200, "csv"},
+		{"GET", "/items", [2]string{"Accept", "application/json"}, 200, "json"},
+		{"GET", "/only", [2]string{"Accept", "text/html"}, 406, ""},
+		{"GET", "/only", [2]string{}, 200, "json"},
+		{"POST", "/only", [2]string{"Content-Type", "text/plain"}, 415, ""},
+		{"POST", "/only", [2]string{"Content-Type", "application/json; charset=utf-8"}, 200, "post"},
+		{"GET", "/any?a", [2]string{}, 200, "any"},
+		{"GET", "/any", [2]string{"X-Tag", "bbb"}, 200, "any"},
+		{"GET", "/any", [2]string{"X-Tag", "c"}, 404, ""},
+	} {
+		req := httptest.NewRequest(tc.method, tc.path, nil)
+		if tc.header[0] != "" {
+			req.Header.Set(tc.header[0], tc.header[1])
+		}
+
+		rec := httptest.NewRecorder()
+		m.ServeHTTP(rec, req)
+
+		if rec.Code != tc.code {
+			t.Errorf("%s %s %q: %v instead of %v", tc.method, tc.path, tc.header, rec.Code, tc.code)
+		} else if tc.body != "" && rec.Body.String() != tc.body {
+			t.Errorf("%s %s %q: %q instead of %q", tc.method, tc.path, tc.header, rec.Body.String(), tc.body)
+		}
+	}
+}
+
+func TestPredicatesPlainFirst(t *testing.T) {
+	h := func(s string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, s)
+		}
+	}
+
+	m := NewRouter(nil)
+	m.HandleFunc("/x", h("plain"))
+	m.When(Accept("application/json")).HandleFunc("/x", h("json"))
+
+	m.MethodFunc("GET", "/y", h("plain"))
+	m.When(Accept("application/json")).MethodFunc("GET", "/y", h("json"))
+
+	m.HandleFunc("/z", h("plain"))
+	m.When(Header("X-A", "1")).MethodFunc("GET", "/z", h("pred"))
+
+	for _, tc := range []struct {
+		method, path string
+		header       [2]string
+		body         string
+	}{
+		{"GET", "/x", [2]string{"Accept", "text/plain"}, "plain"},
+		{"GET", "/x", [2]string{"Accept", "application/json"}, "json"},
+		{"GET", "/y", [2]string{"Accept", "text/plain"}, "plain"},
+		{"GET", "/y", [2]string{"Accept", "application/json"}, "json"},
+		{"HEAD", "/y", [2]string{"Accept", "application/json"}, ""},
+		{"GET", "/z", [2]string{}, "plain"},
+		{"GET", "/z", [2]string{"X-A", "1"}, "pred"},
+		{"POST", "/z", [2]string{}, "plain"},
+	} {
+		req := httptest.NewRequest(tc.method, tc.path, nil)
+		if tc.header[0] != "" {
+			req.Header.Set(tc.header[0], tc.header[1])
+		}
+
+		rec := httptest.NewRecorder()
+		m.ServeHTTP(rec, req)
+
+		if rec.Code != 200 || rec.Body.String() != tc.body {
+			t.Errorf("%s %s %q: %v %q instead of %q", tc.method, tc.path, tc.header,
+				rec.Code, rec.Body.String(), tc.body)
+		}
+	}
+}
+
+func TestPredicateStatus(t *testing.T) {
+	h := func(w http.ResponseWriter, r *http.Request) {}
+
+	m := NewRouter(nil)
+	m.When(ContentType("application/json")).MethodFunc("POST", "/a", h)
+	m.When(ContentType("text/csv")).MethodFunc("POST", "/a", h)
+	m.When(Any(ContentType("application/xml"), ContentType("text/xml"))).MethodFunc("PUT", "/a", h)
+	m.When(Not(Accept("text/html"))).MethodFunc("GET", "/a", h)
+
+	for _, tc := range []struct {
+		method, header, value string
+		code                  int
+		accept, acceptPost    string
+	}{
+		{"POST", "Content-Type", "text/plain", 415, "application/json, text/csv", "application/json, text/csv"},
+		{"PUT", "Content-Type", "text/plain", 415, "application/xml, text/xml", ""},
+		{"PUT", "Content-Type", "text/xml", 204, "", ""},
+		{"GET", "Accept", "text/html", 406, "", ""},
+		{"GET", "Accept", "text/plain", 204, "", ""},
+	} {
+		req := httptest.NewRequest(tc.method, "/a", nil)
+		req.Header.Set(tc.header, tc.value)
+
+		rec := httptest.NewRecorder()
+		m.ServeHTTP(rec, req)
+
+		hdr := rec.Header()
+		if rec.Code != tc.code {
+			t.Errorf("%s %q: %v instead of %v", tc.method, tc.value, rec.Code, tc.code)
+		} else if hdr.Get("Accept") != tc.accept || hdr.Get("Accept-Post") != tc.acceptPost {
+			t.Errorf("%s %q: Accept %q, Accept-Post %q", tc.method, tc.value,
+				hdr.Get("Accept"), hdr.Get("Accept-Post"))
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package router
+
+import (
+	"sort"
+
+	"go.sancus.dev/web/pathparser"
+)
+
+// segmentRank tells how specific a segment of a pattern is,
+// lower is more specific
+type segmentRank int
+
+const (
+	rankLiteral     segmentRank = iota // foo
+	rankConstrained                    // {x:a|b}
+	rankCapture                        // {x}
+	rankStar                           // * and subrouters
+	rankCatchAll                       // {x...}
+)
+
+// patternNode is a node on the non-literal routes list
+type patternNode struct {
+	*node
+
+	seq      int
+	rank     []segmentRank
+	captures []string
+}
+
+// before tells if a pattern takes precedence over another
+func (p *patternNode) before(q *patternNode) bool {
+	if c := compareRank(p.rank, q.rank); c != 0 {
+		return c < 0
+	}
+	return p.seq < q.seq
+}
+
+// rankTemplate describes the specificity of each segment of a pattern
+func rankTemplate(t pathparser.Template) []segmentRank {
+	var rank []segmentRank
+
+	for i, v := range t {
+		switch {
+		case v.Type == pathparser.PartOptional:
+			rank = append(rank, rankTemplate(v.Parts)...)
+		case v.Type != pathparser.PartLiteral || v.Value != "/":
+			// segment content, accounted by the slash before it
+		case i+1 == len(t):
+			// trailing slash, anything bellow
+			rank = append(rank, rankStar)
+		default:
+			switch next := t[i+1]; next.Type {
+			case pathparser.PartLiteral:
+				rank = append(rank, rankLiteral)
+			case pathparser.PartCapture:
+				if len(next.Options) > 0 || next.Constraint != "" {
+					rank = append(rank, rankConstrained)
+				} else {
+					rank = append(rank, rankCapture)
+				}
+			case pathparser.PartStar:
+				rank = append(rank, rankStar)
+			case pathparser.PartCatchAll:
+				rank = append(rank, rankCatchAll)
+			}
+		}
+	}
+
+	return rank
+}
+
+// compareRank compares the specificity of two patterns segment by segment.
+// When one is a prefix of the other, the shorter goes first
+func compareRank(a, b []segmentRank) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return int(a[i] - b[i])
+		}
+	}
+	return len(a) - len(b)
+}
+
+// addPattern inserts a non-literal route after all those with
+// the same or better specificity, so registration order
+// breaks ties
+func (m *Mux) addPattern(n *node, t pathparser.Template) error {
+	l := len(m.pattern)
+	p := &patternNode{
+		node:     n,
+		seq:      l,
+		rank:     rankTemplate(t),
+		captures: t.Captures(),
+	}
+
+	if err := m.addSegments(p, t); err != nil {
+		return err
+	}
+
+	i := sort.Search(l, func(i int) bool {
+		return p.before(m.pattern[i])
+	})
+
+	m.pattern = append(m.pattern, nil)
+	copy(m.pattern[i+1:], m.pattern[i:l])
+	m.pattern[i] = p
+	return nil
+}
@@ -0,0 +1,97 @@
+package router
+
+import (
+	"net/http"
+	"testing"
+)
+
+func newPriorityMux(patterns ...string) *Mux {
+	h := func(w http.ResponseWriter, r *http.Request) {}
+
+	m := NewRouter(nil).(*Mux)
+	for _, s := range patterns {
+		m.HandleFunc(s, h)
+	}
+	return m
+}
+
+func resolvePattern(m *Mux, path string) string {
+	if _, _, n := m.findBestNode(path, nil); n != nil {
+		return n.Pattern
+	}
+	return ""
+}
+
+func testPriority(t *testing.T, path string, expected string, patterns ...string) {
+	// every registration order has to give the same answer
+	for i := range patterns {
+		order := append(append([]string{}, patterns[i:]...), patterns[:i]...)
+
+		m := newPriorityMux(order...)
+		if s := resolvePattern(m, path); s != expected {
+			t.Errorf("%q: %q resolved to %q instead of %q", order, path, s, expected)
+		}
+	}
+}
+
+func TestPriorityLiteralBeatsCapture(t *testing.T) {
+	testPriority(t, "/a/b", "/a/b", "/a/{x}", "/a/b")
+	testPriority(t, "/a/b", "/a/b", "/a/{x:b|c}", "/a/b")
+	testPriority(t, "/a/b/c", "/a/{x}/c", "/a/{x}/c", "/a/{x}/{y}")
+	testPriority(t, "/a/b", "/a/{y}", "/{x}/b", "/a/{y}")
+}
+
+func TestPriorityConstrainedBeatsCapture(t *testing.T) {
+	testPriority(t, "/a/b", "/a/{x:b|c}", "/a/{x}", "/a/{x:b|c}")
+	testPriority(t, "/a/b/c", "/{x:a}/{y}/c", "/{x}/{y:b}/c", "/{x:a}/{y}/c")
+}
+
+func TestPriorityStarLast(t *testing.T) {
+	testPriority(t, "/a/b/c", "/a/{x}/c", "/a/*/c", "/a/{x}/c")
+	testPriority(t, "/a/b/c", "/a/{x:b}/c", "/a/*/c", "/a/{x:b}/c")
+}
+
+func TestPriorityLeafBeatsSubrouter(t *testing.T) {
+	testPriority(t, "/a/b", "/a/{x}", "/a/{x}/", "/a/{x}")
+}
+
+func TestPriorityLongestMatch(t *testing.T) {
+	// specificity only breaks ties between matches of the same length
+	testPriority(t, "/a/b/c", "/{x}/{y}/{z}", "/a/b/", "/{x}/{y}/{z}")
+	testPriority(t, "/a/b/c", "/a/{x}/*", "/a/*", "/a/{x}/*")
+}
+
+func TestPriorityRegistrationOrder(t *testing.T) {
+	m := newPriorityMux("/a/{x}", "/a/{y}", "/a/{z}")
+	for i := 0; i < 100; i++ {
+		if s := resolvePattern(m, "/a/b"); s != "/a/{x}" {
+			t.Fatalf("%q resolved to %q instead of %q", "/a/b", s, "/a/{x}")
+		}
+	}
+
+	m = newPriorityMux("/a/{z}", "/a/{y}", "/a/{x}")
+	if s := resolvePattern(m, "/a/b"); s != "/a/{z}" {
+		t.Errorf("%q resolved to %q instead of %q", "/a/b", s, "/a/{z}")
+	}
+}
+
+func TestPriorityRank(t *testing.T) {
+	for pattern, expected := range map[string][]segmentRank{
+		"/a/{x}":       {rankLiteral, rankCapture},
+		"/a/{x:b|c}/d": {rankLiteral, rankConstrained, rankLiteral},
+		"/*/{x}":       {rankStar, rankCapture},
+		"/a/{x}/*":     {rankLiteral, rankCapture, rankStar},
+		"/a/[{x}]":     {rankLiteral, rankCapture},
+	} {
+		m := newPriorityMux()
+		p, err := m.parsePath(pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rank := rankTemplate(p.Template())
+		if compareRank(rank, expected) != 0 {
+			t.Errorf("%q ranked %v instead of %v", pattern, rank, expected)
+		}
+	}
+}
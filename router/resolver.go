@@ -1,8 +1,6 @@
 package router
 
 import (
-	"path"
-	"regexp"
 	"strings"
 
 	"go.sancus.dev/web"
@@ -10,7 +8,7 @@ import (
 	"go.sancus.dev/web/errors"
 )
 
-func (mux *Mux) findBestNode(path string, args map[string]string) (string, string, *node) {
+func (mux *Mux) findBestNode(path string, args map[string]interface{}) (string, string, *node) {
 	var m nodeMatch
 
 	// literal wins, unless shorter
@@ -31,73 +29,60 @@ func (mux *Mux) findBestNode(path string, args map[string]string) (string, strin
 
 			if l := len(s); path[l] == '/' {
 				// good match
-				m.Set(s, path[l:], h, nil, nil)
+				m.Set(s, path[l:], h)
 			}
 		}
 	}
 
-	// re
-	for re, h := range mux.pattern {
-		if v := re.FindStringSubmatch(path); v != nil {
+	// patterns, by priority
+	sm := segMatch{path: path}
+	mux.segments.match(&sm, 0, 0)
 
-			s := v[0]
-			l := len(s)
-			if l > 0 {
-				// match
+	if p := sm.best; p != nil {
+		l := sm.end
 
-				if s[l-1] == '/' {
-					// remove trailing slash from match
-					l--
-					s = s[:l]
-				}
+		// test if better than the literal match
+		if m.Try(path[:l], path[l:], p.node) {
+			if i, ok := sm.split(); ok {
+				// {name...} handlers get the rest of the path
+				// as RoutePath, like a subrouter
+				m.Set(path[:i-1], path[i-1:], p.node)
+			}
 
-				// test if better than the literal match
-				m.Try(s, path[l:], h, re, v)
+			if args != nil {
+				// return arguments via parameter if requested
+				sm.export(args)
 			}
 		}
 	}
 
-	return m.Return(args)
+	return m.Return()
 }
 
 type nodeMatch struct {
-	Path    string
-	Extra   string
-	Node    *node
-	Expr    *regexp.Regexp
-	Matches []string
+	Path  string
+	Extra string
+	Node  *node
 }
 
-func (m *nodeMatch) Set(s0, s1 string, n *node, re *regexp.Regexp, matches []string) {
+func (m *nodeMatch) Set(s0, s1 string, n *node) {
 	*m = nodeMatch{
-		Path:    s0,
-		Extra:   s1,
-		Node:    n,
-		Expr:    re,
-		Matches: matches,
+		Path:  s0,
+		Extra: s1,
+		Node:  n,
 	}
 }
 
-func (m *nodeMatch) Try(s0, s1 string, n *node, re *regexp.Regexp, matches []string) {
+func (m *nodeMatch) Try(s0, s1 string, n *node) bool {
 	if len(s0) > len(m.Path) {
-		m.Set(s0, s1, n, re, matches)
+		m.Set(s0, s1, n)
+		return true
 	}
+	return false
 }
 
-func (m *nodeMatch) Return(args map[string]string) (string, string, *node) {
-	if len(m.Path) > 0 {
-
-		// return arguments via parameter if requested
-		if args != nil {
-			if re := m.Expr; re != nil {
-				for _, k := range re.SubexpNames() {
-					if j := re.SubexpIndex(k); j > 0 {
-						args[k] = m.Matches[j]
-					}
-				}
-			}
-		}
-
+func (m *nodeMatch) Return() (string, string, *node) {
+	if m.Node != nil {
 		return m.Path, m.Extra, m.Node
 	}
 
@@ -107,7 +92,7 @@ func (m *nodeMatch) Return(args map[string]string) (string, string, *node) {
 
 // Resolve finds the best handler for a path and returns the corresponding RouteContext
 func (m *Mux) Resolve(path string, rctx *context.RoutingContext) (web.Handler, *context.RoutingContext, bool) {
-	args := make(map[string]string)
+	args := make(map[string]interface{})
 	s0, s1, h := m.findBestNode(path, args)
 
 	if h == nil {
@@ -115,12 +100,12 @@ func (m *Mux) Resolve(path string, rctx *context.RoutingContext) (web.Handler, *
 	}
 
 	if s0 != "/" && s1 == "" && strings.HasSuffix(h.Pattern, "/*") {
-		// redirect to the root of the subrouter
-		return errors.NewPermanentRedirect("%s/", m.base(path)), rctx, true
+		// root of the subrouter without the trailing slash
+		return m.resolveRoot(path, rctx)
 	}
 
 	if rctx != nil {
-		rctx = rctx.Step(s0, args)
+		rctx = rctx.StepValues(s0, args)
 	} else {
 		rctx = context.NewRouteContext(s0, s1)
 
@@ -129,9 +114,10 @@ func (m *Mux) Resolve(path string, rctx *context.RoutingContext) (web.Handler, *
 		}
 	}
 
-	return h, rctx, true
-}
+	if s1 == "/" && strings.HasSuffix(h.Pattern, "/*") && m.pathPolicy().Slash != SlashRemove {
+		// root of a subtree, as registered
+		rctx.TrailingSlash = true
+	}
 
-func (m *Mux) base(file string) string {
-	return path.Base(file)
+	return h, rctx, true
 }
@@ -0,0 +1,169 @@
+// Package routertest provides helpers to test the routes of a router.Mux
+package routertest
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"go.sancus.dev/web/router"
+)
+
+// Route is what a path resolves to
+type Route struct {
+	Pattern string // including the prefix of the subrouters
+	Name    string
+	Params  map[string]interface{}
+	Methods []string // "*" for any
+}
+
+// Resolve finds the route of a path on the routing tree without
+// running any handler, following Mux.Resolve through the subrouters.
+// Handlers implementing web.RouterPageInfo aren't asked
+func Resolve(m *router.Mux, path string) (*Route, bool) {
+	p, ok := m.Lookup(path)
+	if !ok {
+		return nil, false
+	}
+
+	return &Route{
+		Pattern: p.Pattern,
+		Name:    p.Name,
+		Params:  p.Params,
+		Methods: p.Methods,
+	}, true
+}
+
+// AssertRoute fails the test unless the path resolves to the pattern
+// with the given params, compared by their converted values, and methods.
+// nil params and no methods aren't checked
+func AssertRoute(t testing.TB, m *router.Mux, path, pattern string, params map[string]interface{}, methods ...string) {
+	t.Helper()
+
+	r, ok := Resolve(m, path)
+	if !ok {
+		t.Errorf("%q: not resolved, expected %q", path, pattern)
+		return
+	} else if r.Pattern != pattern {
+		t.Errorf("%q: resolved to %q instead of %q", path, r.Pattern, pattern)
+	}
+
+	if params != nil {
+		if !equalParams(r.Params, params) {
+			t.Errorf("%q: params %#v instead of %#v", path, r.Params, params)
+		}
+	}
+
+	if len(methods) > 0 {
+		s := append([]string{}, methods...)
+		sort.Strings(s)
+
+		if !reflect.DeepEqual(r.Methods, s) {
+			t.Errorf("%q: methods %q instead of %q", path, r.Methods, s)
+		}
+	}
+}
+
+// AssertNotFound fails the test if the path resolves
+func AssertNotFound(t testing.TB, m *router.Mux, path string) {
+	t.Helper()
+
+	if r, ok := Resolve(m, path); ok {
+		t.Errorf("%q: resolved to %q", path, r.Pattern)
+	}
+}
+
+// equalParams compares params by value and type, treating nil
+// and empty maps alike
+func equalParams(a, b map[string]interface{}) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range b {
+		if w, ok := a[k]; !ok || !reflect.DeepEqual(v, w) {
+			return false
+		}
+	}
+	return true
+}
+
+// Case is a request to run through a handler, and what to expect
+type Case struct {
+	Name   string // of the subtest, method and path if empty
+	Method string // GET if empty
+	Path   string
+	Header http.Header
+	Body   string
+
+	Status       int               // 200 if zero
+	WantHeader   map[string]string // "" to require its absence
+	WantBody     string            // exact match, if set
+	WantContains []string          // fragments of the body
+}
+
+// Run executes each case as a subtest through the full handler,
+// middleware included, and checks the response
+func Run(t *testing.T, h http.Handler, cases []Case) {
+	t.Helper()
+
+	for _, tc := range cases {
+		tc := tc
+
+		if tc.Method == "" {
+			tc.Method = "GET"
+		}
+		if tc.Name == "" {
+			tc.Name = tc.Method + " " + tc.Path
+		}
+
+		t.Run(tc.Name, func(t *testing.T) {
+			tc.run(t, h)
+		})
+	}
+}
+
+func (tc Case) run(t *testing.T, h http.Handler) {
+	var body io.Reader
+
+	if tc.Body != "" {
+		body = strings.NewReader(tc.Body)
+	}
+
+	req := httptest.NewRequest(tc.Method, tc.Path, body)
+	for k, v := range tc.Header {
+		req.Header[k] = v
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	status := tc.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	if rec.Code != status {
+		t.Errorf("status %v instead of %v", rec.Code, status)
+	}
+
+	for k, v := range tc.WantHeader {
+		if s := rec.Header().Get(k); s != v {
+			t.Errorf("%s: %q instead of %q", k, s, v)
+		}
+	}
+
+	s := rec.Body.String()
+	if tc.WantBody != "" && s != tc.WantBody {
+		t.Errorf("body %q instead of %q", s, tc.WantBody)
+	}
+
+	for _, v := range tc.WantContains {
+		if !strings.Contains(s, v) {
+			t.Errorf("body doesn't contain %q: %q", v, s)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package routertest
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.sancus.dev/web/router"
+)
+
+func newMux() *router.Mux {
+	h := func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "%s %s", r.Method, r.URL.Path)
+	}
+
+	stamp := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Stamp", "1")
+			next.ServeHTTP(w, r)
+		})
+	}
+
+	m := router.NewRouter(nil).(*router.Mux)
+	m.Use(stamp)
+	m.Named("home").HandleFunc("/", h)
+	m.Route("/items", func(r router.Router) {
+		r.MethodFunc("GET", "/{id<int>}", h)
+		r.MethodFunc("PUT", "/{id<int>}", h)
+	})
+	return m
+}
+
+func TestResolve(t *testing.T) {
+	m := newMux()
+
+	AssertRoute(t, m, "/", "/", nil, "*")
+	AssertRoute(t, m, "/items/3", "/items/{id<int>}", map[string]interface{}{"id": 3}, "PUT", "GET", "HEAD")
+	AssertNotFound(t, m, "/items/x")
+
+	if r, ok := Resolve(m, "/"); !ok || r.Name != "home" {
+		t.Errorf("bad name: %#v", r)
+	}
+
+	// compared by value and type
+	tb := &recorder{TB: t}
+	AssertRoute(tb, m, "/items/3", "/items/{id<int>}", map[string]interface{}{"id": "3"})
+	if !tb.failed {
+		t.Errorf("%q: string param matched an int", "/items/3")
+	}
+}
+
+// recorder takes the failures of an assertion expected to fail
+type recorder struct {
+	testing.TB
+	failed bool
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(string, ...interface{}) {
+	r.failed = true
+}
+
+// hidden serves requests but tells PageInfo the page doesn't exist
+type hidden struct{}
+
+func (hidden) ServeHTTP(w http.ResponseWriter, r *http.Request) {}
+
+func (hidden) PageInfo(*http.Request) (interface{}, bool) {
+	return nil, false
+}
+
+func TestResolveHidden(t *testing.T) {
+	m := router.NewRouter(nil).(*router.Mux)
+	m.Handle("/hidden/{id<int>}", hidden{})
+
+	AssertRoute(t, m, "/hidden/7", "/hidden/{id<int>}", map[string]interface{}{"id": 7}, "*")
+
+	if _, ok := m.PageInfo(httptest.NewRequest("GET", "/hidden/7", nil)); ok {
+		t.Errorf("%q: PageInfo didn't consult the handler", "/hidden/7")
+	}
+}
+
+func TestRun(t *testing.T) {
+	Run(t, newMux(), []Case{
+		{Path: "/", WantBody: "GET /", WantHeader: map[string]string{"X-Stamp": "1"}},
+		{Method: "PUT", Path: "/items/1", Body: "{}", WantContains: []string{"PUT", "/items/1"}},
+		{Method: "POST", Path: "/items/1", Status: http.StatusMethodNotAllowed},
+		{Path: "/none", Status: http.StatusNotFound},
+	})
+}
@@ -0,0 +1,355 @@
+package router
+
+import (
+	"regexp"
+	"strings"
+
+	"go.sancus.dev/web/errors"
+	"go.sancus.dev/web/pathparser"
+)
+
+// maxCaptures is the maximum number of captures a pattern can have
+const maxCaptures = 32
+
+// segNode is a node of the segment tree used to resolve non-literal
+// routes without regular expressions. Every level corresponds to
+// a segment of the path.
+type segNode struct {
+	literal map[string]*segNode
+	capture []*segCapture
+
+	leaf  []*patternNode // patterns ending here
+	mount []*patternNode // patterns taking anything bellow
+}
+
+// segCapture is a branch of the segment tree matching a {capture} or `*`
+type segCapture struct {
+	segNode
+
+	name       string // empty for `*`
+	key        string
+	rest       bool // {name...}
+	options    []string
+	re         *regexp.Regexp
+	constraint pathparser.Constraint
+}
+
+// segParam is a captured value
+type segParam struct {
+	capture *segCapture
+	value   string
+}
+
+// segMatch holds the state of a segment tree lookup
+type segMatch struct {
+	path string
+	best *patternNode
+	end  int
+
+	count  int
+	params [maxCaptures]segParam
+	stack  [maxCaptures]segParam
+}
+
+func newSegCapture(v pathparser.Part) (*segCapture, error) {
+	c := &segCapture{
+		name: v.Value,
+		key:  v.Value + ":" + strings.Join(v.Options, "|"),
+	}
+
+	if v.Type == pathparser.PartStar {
+		c.name = ""
+		c.key = "*"
+	} else if v.Type == pathparser.PartCatchAll {
+		c.key = v.Value + "..."
+		c.rest = true
+		return c, nil
+	} else if v.Constraint != "" {
+		// typed
+		constraint, ok := pathparser.GetConstraint(v.Constraint)
+		if !ok {
+			return nil, errors.New("%q: constraint %q not registered", v.Value, v.Constraint)
+		}
+
+		c.key = v.Value + "<" + v.Constraint + ">"
+		c.constraint = constraint
+		return c, nil
+	}
+
+	for _, s := range v.Options {
+		if regexp.QuoteMeta(s) != s {
+			// options are regular expressions, but we only
+			// compile them when they aren't plain literals
+			re, err := regexp.Compile("^(" + strings.Join(v.Options, "|") + ")$")
+			if err != nil {
+				return nil, err
+			}
+			c.re = re
+			c.options = nil
+			break
+		}
+		c.options = append(c.options, s)
+	}
+
+	return c, nil
+}
+
+func (c *segCapture) match(s string) bool {
+	switch {
+	case s == "":
+		return false
+	case c.constraint != nil:
+		return c.constraint.Match(s)
+	case c.re != nil:
+		return c.re.MatchString(s)
+	case len(c.options) == 0:
+		return true
+	default:
+		for _, o := range c.options {
+			if s == o {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// insert adds a pattern to the tree
+func (n *segNode) insert(p *patternNode, t pathparser.Template) error {
+	var mount bool
+
+	if l := len(t); l > 0 {
+		if last := t[l-1]; last.Type == pathparser.PartLiteral && last.Value == "/" {
+			// trailing slash, anything bellow
+			t = t[:l-1]
+			mount = true
+		}
+	}
+
+	for _, alt := range expandOptionals(t) {
+		next := n
+
+		for _, v := range splitSegments(alt) {
+			var err error
+
+			if next, err = next.child(v); err != nil {
+				return err
+			}
+		}
+
+		if mount {
+			next.mount = appendPattern(next.mount, p)
+		} else {
+			next.leaf = appendPattern(next.leaf, p)
+		}
+	}
+
+	return nil
+}
+
+func (n *segNode) child(v pathparser.Part) (*segNode, error) {
+	if v.Type == pathparser.PartLiteral {
+		if next, ok := n.literal[v.Value]; ok {
+			return next, nil
+		}
+
+		if n.literal == nil {
+			n.literal = make(map[string]*segNode, 1)
+		}
+
+		next := &segNode{}
+		n.literal[v.Value] = next
+		return next, nil
+	}
+
+	c, err := newSegCapture(v)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c2 := range n.capture {
+		if c2.key == c.key {
+			return &c2.segNode, nil
+		}
+	}
+
+	n.capture = append(n.capture, c)
+	return &c.segNode, nil
+}
+
+func appendPattern(s []*patternNode, p *patternNode) []*patternNode {
+	for _, p2 := range s {
+		if p2 == p {
+			return s
+		}
+	}
+	return append(s, p)
+}
+
+// expandOptionals turns a Template into the list of sequences of parts
+// it can match, like `(/(...)?)?` on the regular expression
+func expandOptionals(t pathparser.Template) [][]pathparser.Part {
+	alts := [][]pathparser.Part{nil}
+
+	for _, v := range t {
+		var options [][]pathparser.Part
+
+		if v.Type != pathparser.PartOptional {
+			options = [][]pathparser.Part{{v}}
+		} else {
+			// absent
+			options = append(options, nil)
+
+			if len(v.Parts) > 0 && v.Parts[0].Type == pathparser.PartLiteral && v.Parts[0].Value == "/" {
+				// only the slash
+				options = append(options, v.Parts[:1])
+			}
+
+			// present
+			options = append(options, expandOptionals(v.Parts)...)
+		}
+
+		next := make([][]pathparser.Part, 0, len(alts)*len(options))
+		for _, a := range alts {
+			for _, b := range options {
+				s := make([]pathparser.Part, 0, len(a)+len(b))
+				s = append(s, a...)
+				s = append(s, b...)
+				next = append(next, s)
+			}
+		}
+		alts = next
+	}
+
+	return alts
+}
+
+// splitSegments turns a sequence of parts into the content of each segment,
+// an empty literal when there is nothing between slashes
+func splitSegments(parts []pathparser.Part) []pathparser.Part {
+	var segments []pathparser.Part
+
+	for _, v := range parts {
+		if v.Type == pathparser.PartLiteral && v.Value == "/" {
+			segments = append(segments, pathparser.Part{
+				Type: pathparser.PartLiteral,
+			})
+		} else if l := len(segments); l > 0 {
+			segments[l-1] = v
+		} else {
+			// not reached, patterns start with a slash
+			segments = append(segments, v)
+		}
+	}
+
+	return segments
+}
+
+// match walks the tree looking for the best pattern for m.path
+func (n *segNode) match(m *segMatch, pos, depth int) {
+	path := m.path
+
+	if pos == len(path) {
+		for _, p := range n.leaf {
+			m.try(p, pos, depth)
+		}
+		for _, p := range n.mount {
+			m.try(p, pos, depth)
+		}
+		return
+	} else if path[pos] != '/' {
+		return
+	}
+
+	for _, p := range n.mount {
+		m.try(p, pos+1, depth)
+	}
+
+	// next segment
+	start := pos + 1
+	end := strings.IndexByte(path[start:], '/')
+	if end < 0 {
+		end = len(path)
+	} else {
+		end += start
+	}
+	s := path[start:end]
+
+	if next, ok := n.literal[s]; ok {
+		next.match(m, end, depth)
+	}
+
+	for _, c := range n.capture {
+		if c.rest {
+			// takes the rest of the path, slashes included
+			if s := path[start:]; s != "" {
+				m.stack[depth] = segParam{c, s}
+				c.segNode.match(m, len(path), depth+1)
+			}
+		} else if c.match(s) {
+			d := depth
+			if c.name != "" {
+				m.stack[d] = segParam{c, s}
+				d++
+			}
+			c.segNode.match(m, end, d)
+		}
+	}
+}
+
+// try considers a pattern matching m.path[:end]
+func (m *segMatch) try(p *patternNode, end, depth int) {
+	if depth > 0 && m.stack[depth-1].capture.rest {
+		// {name...} keeps the trailing slash
+	} else if end > 0 && m.path[end-1] == '/' {
+		// remove trailing slash from match
+		end--
+	}
+
+	if end > m.end || (end == m.end && m.best != nil && p.before(m.best)) {
+		m.best = p
+		m.end = end
+		m.count = depth
+		copy(m.params[:depth], m.stack[:depth])
+	}
+}
+
+// split returns where the {name...} capture of the best match starts,
+// if any
+func (m *segMatch) split() (int, bool) {
+	if m.count > 0 {
+		if v := m.params[m.count-1]; v.capture.rest {
+			return len(m.path) - len(v.value), true
+		}
+	}
+	return 0, false
+}
+
+// export copies the captured values of the best match,
+// converted if typed
+func (m *segMatch) export(args map[string]interface{}) {
+	// optional captures not matched are empty
+	for _, k := range m.best.captures {
+		args[k] = ""
+	}
+
+	for _, v := range m.params[:m.count] {
+		var x interface{} = v.value
+
+		if c := v.capture.constraint; c != nil {
+			if y, err := c.Convert(v.value); err == nil {
+				x = y
+			}
+		}
+
+		args[v.capture.name] = x
+	}
+}
+
+// addSegments inserts a pattern into the Mux's segment tree
+func (m *Mux) addSegments(p *patternNode, t pathparser.Template) error {
+	if l := len(t.Captures()); l > maxCaptures {
+		return errors.New("%q: too many captures (%v > %v)", p.Pattern, l, maxCaptures)
+	}
+	return m.segments.insert(p, t)
+}
@@ -0,0 +1,233 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+// regexpMatcher is the reference implementation the segment tree replaced
+type regexpMatcher struct {
+	mux *Mux
+	re  []*regexp.Regexp
+}
+
+func newRegexpMatcher(m *Mux) *regexpMatcher {
+	rm := &regexpMatcher{mux: m}
+
+	for _, p := range m.pattern {
+		parsed, err := m.parsePath(p.Pattern)
+		if err != nil {
+			panic(err)
+		}
+
+		re, err := parsed.Compile()
+		if err != nil {
+			panic(err)
+		}
+		rm.re = append(rm.re, re)
+	}
+
+	return rm
+}
+
+func (rm *regexpMatcher) findBestNode(path string, args map[string]interface{}) (string, string, *node) {
+	var s0, s1 string
+	var best *node
+	var matches []string
+	var expr *regexp.Regexp
+
+	// literal, like findBestNode()
+	var m nodeMatch
+	if s, _, h := rm.mux.findLiteralNode(path); h != nil {
+		m.Set(s, path[len(s):], h)
+		s0, s1, best = m.Path, m.Extra, m.Node
+	}
+
+	for i, p := range rm.mux.pattern {
+		re := rm.re[i]
+		if v := re.FindStringSubmatch(path); v != nil {
+			s := v[0]
+			l := len(s)
+			if l > 0 {
+				if s[l-1] == '/' {
+					l--
+					s = s[:l]
+				}
+
+				if len(s) > len(s0) {
+					s0, s1, best = s, path[l:], p.node
+					expr, matches = re, v
+				}
+			}
+		}
+	}
+
+	if args != nil && expr != nil {
+		for _, k := range expr.SubexpNames() {
+			if j := expr.SubexpIndex(k); j > 0 {
+				args[k] = matches[j]
+			}
+		}
+	}
+
+	return s0, s1, best
+}
+
+// findLiteralNode is findBestNode() without the patterns
+func (mux *Mux) findLiteralNode(path string) (string, string, *node) {
+	m := &Mux{
+		trie: mux.trie,
+	}
+	return m.findBestNode(path, nil)
+}
+
+func newSegmentTestMux(patterns ...string) *Mux {
+	h := func(w http.ResponseWriter, r *http.Request) {}
+
+	m := NewRouter(nil).(*Mux)
+	for _, s := range patterns {
+		m.HandleFunc(s, h)
+	}
+	return m
+}
+
+func TestSegmentsMatchRegexp(t *testing.T) {
+	m := newSegmentTestMux(
+		"/",
+		"/a",
+		"/a/{x}",
+		"/a/{x:b|c}/d",
+		"/a/{x}/e/",
+		"/a/*/f",
+		"/opt/[{page}]",
+		"/opt2/[b/{c}]/d",
+		"/sub/{x}/",
+		"/lit/",
+		"/{x}/{y}/{z}",
+		"/q/{x:a.c}",
+		"/files/{name:index.html|index.htm}",
+	)
+
+	rm := newRegexpMatcher(m)
+
+	for _, path := range []string{
+		"/", "/a", "/a/", "/a/b", "/a/b/", "/a/b/d", "/a/c/d", "/a/z/d",
+		"/a/b/e", "/a/b/e/", "/a/b/e/g/h", "/a/b/f", "/a/x/f/",
+		"/opt", "/opt/", "/opt/2", "/opt/2/", "/opt/2/3",
+		"/opt2/d", "/opt2//d", "/opt2/b/1/d", "/opt2/b/d",
+		"/sub", "/sub/1", "/sub/1/", "/sub/1/2/3",
+		"/lit", "/lit/", "/lit/x",
+		"/x/y/z", "/x/y/z/", "/x/y", "//y/z",
+		"/q/abc", "/q/a.c", "/q/ac",
+		"/files/index.html", "/files/index.htm", "/files/indexXhtml",
+	} {
+		args0 := make(map[string]interface{})
+		args1 := make(map[string]interface{})
+
+		a0, a1, n0 := rm.findBestNode(path, args0)
+		b0, b1, n1 := m.findBestNode(path, args1)
+
+		if a0 != b0 || a1 != b1 || n0 != n1 {
+			var p0, p1 string
+			if n0 != nil {
+				p0 = n0.Pattern
+			}
+			if n1 != nil {
+				p1 = n1.Pattern
+			}
+
+			t.Errorf("%q: regexp:(%q, %q, %q) segments:(%q, %q, %q)",
+				path, a0, a1, p0, b0, b1, p1)
+		} else if !reflect.DeepEqual(args0, args1) {
+			t.Errorf("%q: regexp:%v segments:%v", path, args0, args1)
+		}
+	}
+}
+
+func TestSegmentsCatchAll(t *testing.T) {
+	m := newSegmentTestMux(
+		"/files/{path...}",
+		"/files/{name}",
+		"/opt/[{rest...}]",
+		"/{any...}",
+	)
+
+	for _, tc := range []struct {
+		path    string
+		prefix  string
+		extra   string
+		pattern string
+		args    map[string]interface{}
+	}{
+		{"/files/a", "/files/a", "", "/files/{name}", map[string]interface{}{"name": "a"}},
+		{"/files/a/", "/files", "/a/", "/files/{path...}", map[string]interface{}{"path": "a/"}},
+		{"/files/a/b/c", "/files", "/a/b/c", "/files/{path...}", map[string]interface{}{"path": "a/b/c"}},
+		{"/opt", "/opt", "", "/opt/[{rest...}]", map[string]interface{}{"rest": ""}},
+		{"/opt/x/y", "/opt", "/x/y", "/opt/[{rest...}]", map[string]interface{}{"rest": "x/y"}},
+		{"/files", "", "/files", "/{any...}", map[string]interface{}{"any": "files"}},
+		{"/", "", "", "", map[string]interface{}{}},
+	} {
+		args := make(map[string]interface{})
+		s0, s1, n := m.findBestNode(tc.path, args)
+
+		var pattern string
+		if n != nil {
+			pattern = n.Pattern
+		}
+
+		if s0 != tc.prefix || s1 != tc.extra || pattern != tc.pattern {
+			t.Errorf("%q: expected (%q, %q, %q), got (%q, %q, %q)",
+				tc.path, tc.prefix, tc.extra, tc.pattern, s0, s1, pattern)
+		} else if !reflect.DeepEqual(args, tc.args) {
+			t.Errorf("%q: expected %v, got %v", tc.path, tc.args, args)
+		}
+	}
+}
+
+// 300 parameterised routes
+func newBenchmarkMux() (*Mux, []string) {
+	var patterns, paths []string
+
+	for i := 0; i < 100; i++ {
+		patterns = append(patterns,
+			fmt.Sprintf("/api/v1/res%v/{id}", i),
+			fmt.Sprintf("/api/v1/res%v/{id}/items/{item}", i),
+			fmt.Sprintf("/api/v1/res%v/{id}/{action:edit|delete}", i),
+		)
+
+		if i%10 == 0 {
+			paths = append(paths,
+				fmt.Sprintf("/api/v1/res%v/42", i),
+				fmt.Sprintf("/api/v1/res%v/42/items/7", i),
+				fmt.Sprintf("/api/v1/res%v/42/edit", i),
+				fmt.Sprintf("/api/v1/res%v/42/unknown", i),
+			)
+		}
+	}
+
+	return newSegmentTestMux(patterns...), paths
+}
+
+func BenchmarkFindBestNodeSegments(b *testing.B) {
+	m, paths := newBenchmarkMux()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.findBestNode(paths[i%len(paths)], nil)
+	}
+}
+
+func BenchmarkFindBestNodeRegexp(b *testing.B) {
+	m, paths := newBenchmarkMux()
+	rm := newRegexpMatcher(m)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rm.findBestNode(paths[i%len(paths)], nil)
+	}
+}
@@ -46,14 +46,21 @@ func (r *router) TryMethodFunc(method string, path string, h web.HandlerFunc) {
 }
 
 func (r *router) Route(path string, fn func(Router)) Router {
+	return r.getNode(subtree(path)).route(fn)
+}
+
+func (r *router) Mount(path string, h http.Handler) {
+	r.getNode(subtree(path)).mount(h)
+}
 
+// subtree turns a path into a foo/* pattern
+func subtree(path string) string {
 	if strings.HasSuffix(path, "/") {
 		path += "*"
 	} else if !strings.HasSuffix(path, "/*") {
 		path += "/*"
 	}
-
-	return r.getNode(path).route(fn)
+	return path
 }
 
 // just a node on the trie but allowing it to
@@ -62,6 +69,12 @@ type node struct {
 	Handler
 
 	Pattern string
+	Name    string
+
+	// for introspection
+	handlers   []handlerInfo
+	middleware []web.MiddlewareHandlerFunc // Use(), for routers
+	groups     []string                    // applied to the router
 }
 
 func (n *node) toolate(fn string) {
@@ -119,6 +132,36 @@ func (n *node) with(middleware ...web.MiddlewareHandlerFunc) {
 	}
 }
 
+func (n *node) group(names ...string) {
+	if v, ok := n.Handler.(interface {
+		group(...string)
+	}); ok {
+		v.group(names...)
+	} else {
+		n.toolate("With")
+	}
+}
+
+func (n *node) when(preds ...Predicate) {
+	if v, ok := n.Handler.(interface {
+		when(...Predicate)
+	}); ok {
+		v.when(preds...)
+	} else {
+		n.toolate("When")
+	}
+}
+
+func (n *node) mount(h http.Handler) {
+	if v, ok := n.Handler.(interface {
+		mount(http.Handler)
+	}); ok {
+		v.mount(h)
+	} else {
+		n.toolate("Mount")
+	}
+}
+
 func (n *node) route(fn func(Router)) Router {
 	if v, ok := n.Handler.(interface {
 		route(func(Router)) Router
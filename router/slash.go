@@ -0,0 +1,240 @@
+package router
+
+import (
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"go.sancus.dev/web"
+	"go.sancus.dev/web/context"
+	"go.sancus.dev/web/errors"
+)
+
+// SlashPolicy tells what to do when a path is only known with, or
+// without, a trailing slash
+type SlashPolicy int
+
+const (
+	// SlashDefault only redirects the roots of subrouters requested
+	// without the trailing slash, using a relative Location. Other
+	// paths are taken exactly as registered
+	SlashDefault SlashPolicy = iota
+	// SlashAdd redirects to the path with the trailing slash when
+	// only that one exists, subrouter roots and routes alike
+	SlashAdd
+	// SlashStrict only takes paths exactly as registered
+	SlashStrict
+	// SlashRemove redirects to the path without the trailing slash
+	// when only that one exists
+	SlashRemove
+	// SlashMatch serves either form without redirecting
+	SlashMatch
+)
+
+// PathPolicy tells how a Mux deals with paths that aren't exactly
+// like the registered ones
+type PathPolicy struct {
+	Slash  SlashPolicy
+	Clean  bool // redirect //, /./, /../ and non-normalised escapes
+	Status int  // of the redirects, 308 if not set
+}
+
+// WithPathPolicy sets the PathPolicy of the Mux. Subrouters
+// created by Route() follow it unless they set their own.
+func (m *Mux) WithPathPolicy(p PathPolicy) *Mux {
+	m.policy = &p
+	return m
+}
+
+func (m *Mux) pathPolicy() PathPolicy {
+	for ; m != nil; m = m.parent {
+		if m.policy != nil {
+			return *m.policy
+		}
+	}
+	return PathPolicy{}
+}
+
+// redirect sends the client to another path, keeping the query string
+func (p PathPolicy) redirect(r *http.Request, path string) error {
+	code := p.Status
+	if !errors.CodeIsRedirect(code) {
+		code = http.StatusPermanentRedirect
+	}
+
+	if q := r.URL.RawQuery; q != "" {
+		path += "?" + q
+	}
+	return errors.NewRedirect(code, path)
+}
+
+// slashRedirect is the handler of subrouter roots requested
+// without the trailing slash
+type slashRedirect struct {
+	policy PathPolicy
+}
+
+func (h slashRedirect) TryServeHTTP(w http.ResponseWriter, r *http.Request) error {
+	escaped := r.URL.EscapedPath()
+	if h.policy.Slash == SlashDefault {
+		// relative, as it always was
+		return h.policy.redirect(r, path.Base(escaped)+"/")
+	}
+	return h.policy.redirect(r, escaped+"/")
+}
+
+// resolveRoot handles subrouters requested without the trailing slash
+func (m *Mux) resolveRoot(path string, rctx *context.RoutingContext) (web.Handler, *context.RoutingContext, bool) {
+	policy := m.pathPolicy()
+
+	switch policy.Slash {
+	case SlashStrict:
+		return nil, nil, false
+	case SlashRemove, SlashMatch:
+		if rctx != nil {
+			rctx = rctx.Clone()
+			rctx.RoutePath += "/"
+		}
+
+		h, rctx, ok := m.Resolve(path+"/", rctx)
+		if ok {
+			// the slash is ours
+			rctx.TrailingSlash = true
+		}
+		return h, rctx, ok
+	default:
+		return slashRedirect{policy}, rctx, true
+	}
+}
+
+// trySlash attempts the other form of a path that couldn't be resolved
+func (m *Mux) trySlash(w http.ResponseWriter, r *http.Request, rctx *context.RoutingContext) (bool, error) {
+	var alt, location string
+
+	policy := m.pathPolicy()
+	path := rctx.RoutePath
+	escaped := r.URL.EscapedPath()
+
+	switch {
+	case path == "" || path == "/":
+		return false, nil
+	case strings.HasSuffix(path, "/"):
+		if policy.Slash != SlashRemove && policy.Slash != SlashMatch {
+			return false, nil
+		}
+		alt = strings.TrimSuffix(path, "/")
+		location = strings.TrimSuffix(escaped, "/")
+	default:
+		if policy.Slash != SlashAdd && policy.Slash != SlashMatch {
+			return false, nil
+		}
+		alt = path + "/"
+		location = escaped + "/"
+	}
+
+	if !m.exists(alt) {
+		return false, nil
+	} else if policy.Slash != SlashMatch {
+		return true, policy.redirect(r, location)
+	}
+
+	rctx = rctx.Clone()
+	rctx.RoutePath = alt
+
+	h, rctx, ok := m.Resolve(alt, rctx)
+	if !ok {
+		return false, nil
+	}
+
+	r = r.WithContext(context.WithRouteContext(r.Context(), rctx))
+	return true, h.TryServeHTTP(w, r)
+}
+
+// exists tells if a path matches a route exactly, or the root of a subtree
+func (m *Mux) exists(path string) bool {
+	_, s1, n := m.findBestNode(path, nil)
+
+	switch {
+	case n == nil:
+		return false
+	case s1 == "":
+		return true
+	default:
+		return s1 == "/" && strings.HasSuffix(n.Pattern, "/*")
+	}
+}
+
+// cleanPath returns the normalised form of an escaped path
+func cleanPath(s string) string {
+	var b strings.Builder
+
+	// percent-encoding, RFC 3986 section 6.2.2
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == '%' && i+2 < len(s) && ishex(s[i+1]) && ishex(s[i+2]) {
+			v := unhex(s[i+1])<<4 | unhex(s[i+2])
+			if unreserved(v) {
+				b.WriteByte(v)
+			} else {
+				b.WriteByte('%')
+				b.WriteString(strings.ToUpper(s[i+1 : i+3]))
+			}
+			i += 2
+		} else {
+			b.WriteByte(c)
+		}
+	}
+
+	// dot segments and empty segments
+	p := path.Clean(b.String())
+	if p != "/" && strings.HasSuffix(s, "/") {
+		p += "/"
+	}
+	return p
+}
+
+// tryClean redirects requests with paths that aren't normalised
+func (m *Mux) tryClean(r *http.Request) error {
+	policy := m.pathPolicy()
+	if !policy.Clean {
+		return nil
+	}
+
+	escaped := r.URL.EscapedPath()
+	if s := cleanPath(escaped); s != escaped {
+		if _, err := url.PathUnescape(s); err == nil {
+			return policy.redirect(r, s)
+		}
+	}
+	return nil
+}
+
+func ishex(c byte) bool {
+	switch {
+	case '0' <= c && c <= '9', 'a' <= c && c <= 'f', 'A' <= c && c <= 'F':
+		return true
+	default:
+		return false
+	}
+}
+
+func unhex(c byte) byte {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0'
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10
+	default:
+		return c - 'A' + 10
+	}
+}
+
+func unreserved(c byte) bool {
+	switch {
+	case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		return true
+	default:
+		return c == '-' || c == '.' || c == '_' || c == '~'
+	}
+}
@@ -0,0 +1,79 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newSlashMux(p PathPolicy) *Mux {
+	h := func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, r.URL.Path)
+	}
+
+	m := NewRouter(nil).(*Mux).WithPathPolicy(p)
+	m.HandleFunc("/about", h)
+	m.HandleFunc("/docs/", h)
+	m.Route("/api", func(r Router) {
+		r.HandleFunc("/", h)
+		r.HandleFunc("/items/{id}", h)
+	})
+	return m
+}
+
+func TestPathPolicy(t *testing.T) {
+	for _, tc := range []struct {
+		policy   PathPolicy
+		path     string
+		code     int
+		location string
+	}{
+		{PathPolicy{}, "/about", 200, ""},
+		{PathPolicy{}, "/about/", 404, ""},
+		{PathPolicy{}, "/docs", 308, "docs/"},
+		{PathPolicy{}, "/api", 308, "api/"},
+		{PathPolicy{}, "/api?x=1", 308, "api/?x=1"},
+		{PathPolicy{}, "/api/items/1/", 404, ""},
+		{PathPolicy{}, "/api//items/1", 404, ""},
+		{PathPolicy{Slash: SlashAdd}, "/docs?x=1", 308, "/docs/?x=1"},
+		{PathPolicy{Slash: SlashAdd}, "/api?x=1", 308, "/api/?x=1"},
+		{PathPolicy{Slash: SlashStrict}, "/api", 404, ""},
+		{PathPolicy{Slash: SlashRemove}, "/about/?q", 308, "/about?q"},
+		{PathPolicy{Slash: SlashRemove}, "/api/items/1/", 308, "/api/items/1"},
+		{PathPolicy{Slash: SlashRemove}, "/api", 200, ""},
+		{PathPolicy{Slash: SlashMatch}, "/about/", 200, ""},
+		{PathPolicy{Slash: SlashMatch}, "/api/items/1/", 200, ""},
+		{PathPolicy{Slash: SlashMatch}, "/docs", 200, ""},
+		{PathPolicy{Status: http.StatusMovedPermanently}, "/api", 301, "api/"},
+		{PathPolicy{Clean: true}, "/api//items/./x/../1?q", 308, "/api/items/1?q"},
+		{PathPolicy{Clean: true}, "/api/items/%7euser%2f", 308, "/api/items/~user%2F"},
+		{PathPolicy{Clean: true}, "/api/items/%3A", 200, ""},
+	} {
+		m := newSlashMux(tc.policy)
+
+		rec := httptest.NewRecorder()
+		m.ServeHTTP(rec, httptest.NewRequest("GET", tc.path, nil))
+
+		if rec.Code != tc.code {
+			t.Errorf("%+v %q: %v instead of %v", tc.policy, tc.path, rec.Code, tc.code)
+		} else if s := rec.Header().Get("Location"); s != tc.location {
+			t.Errorf("%+v %q: redirected to %q instead of %q", tc.policy, tc.path, s, tc.location)
+		}
+	}
+}
+
+func TestCleanPath(t *testing.T) {
+	for _, tc := range [][2]string{
+		{"/", "/"},
+		{"//a//b/", "/a/b/"},
+		{"/a/./b/../c", "/a/c"},
+		{"/../a", "/a"},
+		{"/%41%2d%2f%3a", "/A-%2F%3A"},
+		{"/%zz", "/%zz"},
+	} {
+		if s := cleanPath(tc[0]); s != tc[1] {
+			t.Errorf("%q: %q instead of %q", tc[0], s, tc[1])
+		}
+	}
+}
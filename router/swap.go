@@ -0,0 +1,142 @@
+package router
+
+import (
+	"net/http"
+	"sync"
+	"sync/atomic"
+
+	"go.sancus.dev/web"
+	"go.sancus.dev/web/errors"
+)
+
+// Swapper is a stable Handler serving through another that can be
+// replaced atomically at any time. Requests in flight finish on the
+// Handler they started with.
+type Swapper struct {
+	mu sync.Mutex // only for writers
+	v  atomic.Value
+}
+
+// atomic.Value wants the same concrete type every time
+type swapped struct {
+	h Handler
+}
+
+// NewSwapper creates a Swapper serving a given Handler
+func NewSwapper(h Handler) *Swapper {
+	s := &Swapper{}
+	s.Swap(h)
+	return s
+}
+
+// Swap replaces the Handler, returning the previous one. Routers
+// are compiled before being published
+func (s *Swapper) Swap(h Handler) Handler {
+	if v, ok := h.(interface {
+		compileAll()
+	}); ok {
+		v.compileAll()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	old := s.Handler()
+	s.v.Store(swapped{h})
+	return old
+}
+
+// Handler returns the Handler currently being served
+func (s *Swapper) Handler() Handler {
+	v, _ := s.v.Load().(swapped)
+	return v.h
+}
+
+// Rebuild builds a new Router and swaps it in, unless fn fails or
+// the registration panics, in which case the current one stays
+func (s *Swapper) Rebuild(eh web.ErrorHandlerFunc, fn func(Router) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if e, ok := r.(error); ok {
+				err = e
+			} else {
+				err = errors.New("%v", r)
+			}
+		}
+	}()
+
+	r := NewRouter(eh)
+	if fn != nil {
+		if err = fn(r); err != nil {
+			return err
+		}
+	}
+
+	s.Swap(r)
+	return nil
+}
+
+func (s *Swapper) TryServeHTTP(w http.ResponseWriter, r *http.Request) error {
+	if h := s.Handler(); h != nil {
+		return h.TryServeHTTP(w, r)
+	}
+	return errors.ErrNotFound
+}
+
+func (s *Swapper) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h := s.Handler(); h != nil {
+		h.ServeHTTP(w, r)
+	} else {
+		errors.HandleError(w, r, errors.ErrNotFound)
+	}
+}
+
+// PageInfo asks the current Handler, if it can tell
+func (s *Swapper) PageInfo(r *http.Request) (interface{}, bool) {
+	if v, ok := s.Handler().(web.RouterPageInfo); ok {
+		return v.PageInfo(r)
+	}
+	return nil, false
+}
+
+// compileAll compiles every node ahead of time, so the Mux can be
+// shared by concurrent requests without racing to do it lazily
+func (m *Mux) compileAll() {
+	compileNode(&m.node)
+
+	m.eachNode(func(n *node) bool {
+		if sub := n.router(); sub != nil {
+			sub.compileAll()
+		}
+
+		// and the routers behind handlers, like VersionMux
+		for _, v := range n.handlers {
+			if h, ok := v.handler.(interface {
+				compileAll()
+			}); ok {
+				h.compileAll()
+			}
+		}
+
+		compileNode(n)
+		return false
+	})
+}
+
+func (m *HostMux) compileAll() {
+	for _, p := range m.hosts {
+		p.router().compileAll()
+	}
+
+	if m.fallback != nil {
+		m.fallback.router().compileAll()
+	}
+}
+
+func compileNode(n *node) {
+	if v, ok := n.Handler.(interface {
+		compile() Handler
+	}); ok {
+		v.compile()
+	}
+}
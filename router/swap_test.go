@@ -0,0 +1,121 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func newEchoRouter(name string) Router {
+	r := NewRouter(nil)
+	r.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, name)
+	})
+	return r
+}
+
+func TestSwapper(t *testing.T) {
+	serve := func(h http.Handler) (int, string) {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+		return rec.Code, rec.Body.String()
+	}
+
+	s := NewSwapper(nil)
+	if code, _ := serve(s); code != 404 {
+		t.Errorf("empty Swapper: %v", code)
+	}
+
+	a, b := newEchoRouter("a"), newEchoRouter("b")
+	if old := s.Swap(a); old != nil {
+		t.Errorf("unexpected previous %v", old)
+	} else if old := s.Swap(b); old != a {
+		t.Errorf("previous %v instead of a", old)
+	} else if _, body := serve(s); body != "b" {
+		t.Errorf("serving %q", body)
+	}
+
+	// failed rebuilds keep the current router
+	if err := s.Rebuild(nil, func(r Router) error {
+		return fmt.Errorf("failed")
+	}); err == nil {
+		t.Error("error lost")
+	}
+
+	if err := s.Rebuild(nil, func(r Router) error {
+		r.HandleFunc("/", nil)
+		panic("bad registration")
+	}); err == nil {
+		t.Error("panic lost")
+	}
+
+	if _, body := serve(s); body != "b" {
+		t.Errorf("serving %q after failed rebuilds", body)
+	}
+}
+
+func TestSwapperConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+
+	a, b := newEchoRouter("a"), newEchoRouter("b")
+	s := NewSwapper(a)
+	done := make(chan struct{})
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+
+				rec := httptest.NewRecorder()
+				s.ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+				if body := rec.Body.String(); rec.Code != 200 || (body != "a" && body != "b") {
+					t.Errorf("served %v %q during swap", rec.Code, body)
+					return
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < 200; i++ {
+		if i%2 == 0 {
+			s.Swap(b)
+		} else {
+			s.Swap(a)
+		}
+	}
+
+	close(done)
+	wg.Wait()
+}
+
+// compiled is a handler with routers of its own
+type compiled struct {
+	http.HandlerFunc
+	done bool
+}
+
+func (h *compiled) compileAll() {
+	h.done = true
+}
+
+func TestSwapperCompilesHandlers(t *testing.T) {
+	h := &compiled{HandlerFunc: func(http.ResponseWriter, *http.Request) {}}
+
+	m := NewRouter(nil).(*Mux)
+	m.Handle("/api/*", h)
+
+	NewSwapper(m)
+
+	if !h.done {
+		t.Error("handler not compiled")
+	}
+}
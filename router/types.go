@@ -11,15 +11,29 @@ type Handler interface {
 	web.Handler
 }
 
+// Router is implemented by Mux.
+//
+// URL() was added for named routes, breaking implementations outside
+// this package. Embedding a Router keeps them building.
 type Router interface {
 	Handler
 	MiniRouter
 
 	Use(web.MiddlewareHandlerFunc) Router
 
+	NotFound(web.ErrorHandlerFunc) Router
+	MethodNotAllowed(web.ErrorHandlerFunc) Router
+
 	Walk(fn WalkFn)
+
+	URL(name string, params map[string]interface{}) (string, error)
 }
 
+// MiniRouter is the registration part of a Router, also implemented
+// by the Chain returned by With() and Named().
+//
+// Named() was added for named routes, breaking implementations
+// outside this package.
 type MiniRouter interface {
 	Handle(path string, handler http.Handler)
 	HandleFunc(path string, handler http.HandlerFunc)
@@ -34,6 +48,9 @@ type MiniRouter interface {
 	TryMethodFunc(method string, path string, handler web.HandlerFunc)
 
 	With(web.MiddlewareHandlerFunc) MiniRouter
+	Named(name string) MiniRouter
+	When(preds ...Predicate) MiniRouter
 
 	Route(path string, fn func(Router)) Router
+	Mount(path string, handler http.Handler)
 }
@@ -0,0 +1,122 @@
+package router
+
+import (
+	"strings"
+
+	"go.sancus.dev/web/errors"
+)
+
+// Named returns a MiniRouter that assigns the given name to the
+// routes registered through it
+func (m *Mux) Named(name string) MiniRouter {
+	chain := &Chain{}
+	chain.init(m)
+	return chain.Named(name)
+}
+
+func (m *Mux) setName(name string, n *node) {
+	if name == "" {
+		return
+	} else if n2, ok := m.names[name]; ok && n2 != n {
+		panic(errors.New("route name %q already used by %q", name, n2.Pattern))
+	} else if n.Name != "" && n.Name != name {
+		panic(errors.New("route %q already named %q", n.Pattern, n.Name))
+	}
+
+	if m.names == nil {
+		m.names = make(map[string]*node, 1)
+	}
+
+	n.Name = name
+	m.names[name] = n
+}
+
+// URL renders the path of a named route, including the prefix of
+// the subrouters it was registered on
+func (m *Mux) URL(name string, params map[string]interface{}) (string, error) {
+	if s, err, ok := m.url(name, params); ok {
+		return s, err
+	}
+
+	return "", errors.New("route %q not found", name)
+}
+
+func (m *Mux) url(name string, values map[string]interface{}) (string, error, bool) {
+	// ours
+	if n, ok := m.names[name]; ok {
+		s, err := m.expand(n.Pattern, values)
+		return s, err, true
+	}
+
+	// or from a subrouter
+	var s string
+	var err error
+	var ok bool
+
+	m.eachNode(func(n *node) bool {
+		var prefix string
+
+		if sub := n.router(); sub == nil {
+			return false
+		} else if s, err, ok = sub.url(name, values); !ok {
+			return false
+		} else if err != nil {
+			return true
+		}
+
+		prefix, err = m.expand(strings.TrimSuffix(n.Pattern, "/*"), values)
+		if err == nil && prefix != "/" {
+			s = prefix + s
+		}
+		return true
+	})
+
+	return s, err, ok
+}
+
+// expand renders a pattern into a path
+func (m *Mux) expand(pattern string, values map[string]interface{}) (string, error) {
+	if pattern == "" {
+		return "/", nil
+	}
+
+	p, err := m.parsePath(pattern)
+	if err != nil {
+		return "", err
+	} else if t := p.Template(); t != nil {
+		return t.Expand(values)
+	} else {
+		return p.Path(), nil
+	}
+}
+
+// eachNode calls a function for each node of the Mux until it returns true
+func (m *Mux) eachNode(fn func(*node) bool) {
+	var done bool
+
+	m.trie.Walk(func(_ string, v interface{}) bool {
+		done = fn(v.(*node))
+		return done
+	})
+
+	if !done {
+		for _, n := range m.pattern {
+			if fn(n.node) {
+				return
+			}
+		}
+	}
+}
+
+// router returns the subrouter mounted on a node, if any
+func (n *node) router() *Mux {
+	switch v := n.Handler.(type) {
+	case *Mux:
+		return v
+	case *rawNode:
+		if m, ok := v.h.(*Mux); ok {
+			return m
+		}
+	}
+	return nil
+}
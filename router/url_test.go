@@ -0,0 +1,104 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestURL(t *testing.T) {
+	echo := func(name string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, name)
+		}
+	}
+
+	m := NewRouter(nil).(*Mux)
+	m.Named("home").HandleFunc("/", echo("home"))
+	m.Named("item").HandleFunc("/items/{id}", echo("item"))
+	m.Named("kind").HandleFunc("/kinds/{kind:a|b}", echo("kind"))
+	m.Named("archive").HandleFunc("/archive/[{year}/[{month}]]", echo("archive"))
+	m.Route("/users/{user}", func(r Router) {
+		r.Named("post").HandleFunc("/posts/{id}", echo("post"))
+	})
+
+	for _, tc := range []struct {
+		name   string
+		params map[string]interface{}
+		url    string
+		fails  bool
+	}{
+		{"home", nil, "/", false},
+		{"item", map[string]interface{}{"id": 42}, "/items/42", false},
+		{"kind", map[string]interface{}{"kind": "b"}, "/kinds/b", false},
+		{"archive", nil, "/archive", false},
+		{"archive", map[string]interface{}{"year": 2026}, "/archive/2026", false},
+		{"archive", map[string]interface{}{"year": 2026, "month": 10}, "/archive/2026/10", false},
+		{"post", map[string]interface{}{"user": "a b", "id": "x%"}, "/users/a%20b/posts/x%25", false},
+		{"item", nil, "", true},                                              // missing param
+		{"kind", map[string]interface{}{"kind": "c"}, "", true},              // not an option
+		{"post", map[string]interface{}{"user": "a/b", "id": "x"}, "", true}, // slash in capture
+		{"unknown", nil, "", true},
+	} {
+		s, err := m.URL(tc.name, tc.params)
+		if tc.fails {
+			if err == nil {
+				t.Errorf("%q %v: unexpected %q", tc.name, tc.params, s)
+			}
+			continue
+		} else if err != nil {
+			t.Errorf("%q %v: %s", tc.name, tc.params, err)
+			continue
+		} else if s != tc.url {
+			t.Errorf("%q %v: %q instead of %q", tc.name, tc.params, s, tc.url)
+			continue
+		}
+
+		// round trip
+		rec := httptest.NewRecorder()
+		m.ServeHTTP(rec, httptest.NewRequest("GET", s, nil))
+		if body := rec.Body.String(); body != tc.name {
+			t.Errorf("%q: %q served by %q", tc.name, s, body)
+		}
+	}
+}
+
+func TestURLConstraint(t *testing.T) {
+	m := NewRouter(nil).(*Mux)
+	m.Named("item").HandleFunc("/items/{id<int>}", func(http.ResponseWriter, *http.Request) {})
+
+	if s, err := m.URL("item", map[string]interface{}{"id": 42}); err != nil || s != "/items/42" {
+		t.Errorf("%q, %v", s, err)
+	}
+
+	if s, err := m.URL("item", map[string]interface{}{"id": "x"}); err == nil {
+		t.Errorf("constraint mismatch accepted: %q", s)
+	}
+}
+
+func TestURLCatchAll(t *testing.T) {
+	m := NewRouter(nil).(*Mux)
+	m.Route("/users/{user}", func(r Router) {
+		r.Named("file").HandleFunc("/files/{path...}", func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, r.URL.Path)
+		})
+	})
+
+	s, err := m.URL("file", map[string]interface{}{"user": "a b", "path": "x y/z%"})
+	if err != nil {
+		t.Fatal(err)
+	} else if s != "/users/a%20b/files/x%20y/z%25" {
+		t.Errorf("%q", s)
+	}
+
+	rec := httptest.NewRecorder()
+	m.ServeHTTP(rec, httptest.NewRequest("GET", s, nil))
+	if body := rec.Body.String(); body != "/users/a b/files/x y/z%" {
+		t.Errorf("%q served %q", s, body)
+	}
+
+	if s, err := m.URL("file", map[string]interface{}{"user": "a", "path": ""}); err == nil {
+		t.Errorf("empty catch-all accepted: %q", s)
+	}
+}
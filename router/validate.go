@@ -0,0 +1,363 @@
+package router
+
+import (
+	"fmt"
+	"strings"
+
+	"go.sancus.dev/web/pathparser"
+)
+
+// RouteErrorKind tells what kind of problem a RouteError describes
+type RouteErrorKind uint
+
+const (
+	// RouteSyntaxError is a malformed pattern
+	RouteSyntaxError RouteErrorKind = iota + 1
+	// RouteOverlap is a pattern matching paths another pattern also does
+	RouteOverlap
+	// RouteShadowed is a literal route losing paths to a pattern
+	RouteShadowed
+	// RouteDuplicate is a handler registered twice for the same method
+	RouteDuplicate
+)
+
+func (k RouteErrorKind) String() string {
+	switch k {
+	case RouteSyntaxError:
+		return "syntax error"
+	case RouteOverlap:
+		return "overlap"
+	case RouteShadowed:
+		return "shadowed"
+	case RouteDuplicate:
+		return "duplicate"
+	default:
+		return fmt.Sprintf("RouteErrorKind(%d)", uint(k))
+	}
+}
+
+// RouteError is a problem found on the routes of a Mux
+type RouteError struct {
+	Kind    RouteErrorKind
+	Pattern string // including the prefix of the subrouter
+	Other   string // the other pattern involved, if any
+	Method  string // for RouteDuplicate, "*" for any
+	Column  int    // for RouteSyntaxError, starting at 1 if known
+	Err     error  // for RouteSyntaxError
+}
+
+func (e *RouteError) Error() string {
+	switch e.Kind {
+	case RouteSyntaxError:
+		if e.Column > 0 {
+			return fmt.Sprintf("%q: syntax error at column %v", e.Pattern, e.Column)
+		}
+		return fmt.Sprintf("%q: %s", e.Pattern, e.Err)
+	case RouteOverlap:
+		return fmt.Sprintf("%q: overlaps %q", e.Pattern, e.Other)
+	case RouteShadowed:
+		return fmt.Sprintf("%q: shadowed by %q", e.Pattern, e.Other)
+	case RouteDuplicate:
+		return fmt.Sprintf("%q: %s handler registered twice", e.Pattern, e.Method)
+	default:
+		return fmt.Sprintf("%q: %s", e.Pattern, e.Kind)
+	}
+}
+
+func (e *RouteError) Unwrap() error {
+	return e.Err
+}
+
+// RouteErrors is the list of problems found by Mux.Validate()
+type RouteErrors []*RouteError
+
+func (errs RouteErrors) Error() string {
+	s := make([]string, len(errs))
+	for i, e := range errs {
+		s[i] = e.Error()
+	}
+	return strings.Join(s, "\n")
+}
+
+// Kind returns the errors of the given kind
+func (errs RouteErrors) Kind(kind RouteErrorKind) RouteErrors {
+	var out RouteErrors
+	for _, e := range errs {
+		if e.Kind == kind {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// WithValidation switches the Mux into validation mode. Problems found
+// while registering routes are collected for Validate() instead of
+// causing a panic, and subrouters created afterwards inherit the mode.
+func (m *Mux) WithValidation() *Mux {
+	m.validation = true
+	return m
+}
+
+// Validate checks the routes of the Mux and its subrouters, and returns
+// the problems found, including those collected on validation mode
+func (m *Mux) Validate() RouteErrors {
+	return m.validate("")
+}
+
+func (m *Mux) validate(prefix string) RouteErrors {
+	var errs RouteErrors
+
+	add := func(e RouteError) {
+		e.Pattern = prefix + e.Pattern
+		if e.Other != "" {
+			e.Other = prefix + e.Other
+		}
+		errs = append(errs, &e)
+	}
+
+	for _, e := range m.problems {
+		add(*e)
+	}
+
+	m.checkPatterns(add)
+
+	// subrouters
+	m.eachNode(func(n *node) bool {
+		if sub := n.router(); sub != nil {
+			s := prefix + strings.TrimSuffix(n.Pattern, "/*")
+			errs = append(errs, sub.validate(s)...)
+		}
+		return false
+	})
+
+	return errs
+}
+
+// report records a problem in validation mode, or panics
+func (m *Mux) report(e *RouteError) {
+	if !m.validation {
+		if e.Err != nil {
+			panic(e.Err)
+		}
+		panic(e)
+	}
+
+	m.problems = append(m.problems, e)
+}
+
+// invalidNode reports a pattern that couldn't be registered, and returns
+// a node not connected to the Mux so the registration can continue
+func (m *Mux) invalidNode(pattern string, err error) *node {
+	e := &RouteError{
+		Kind:    RouteSyntaxError,
+		Pattern: pattern,
+		Err:     err,
+	}
+
+	if v, ok := err.(*pathparser.SyntaxError); ok {
+		e.Column = v.Column
+	}
+
+	m.report(e)
+
+	n := &node{
+		Pattern: pattern,
+	}
+	n.initRaw(m)
+	return n
+}
+
+// duplicate reports a method registered twice on validation mode,
+// otherwise the last handler wins
+func (m *Mux) duplicate(pattern, method string) {
+	if m.validation {
+		m.report(&RouteError{
+			Kind:    RouteDuplicate,
+			Pattern: pattern,
+			Method:  method,
+		})
+	}
+}
+
+// routeShape is an alternative sequence of segments a route can match
+type routeShape struct {
+	segments []pathparser.Part
+	mount    bool
+}
+
+func (m *Mux) shapes(pattern string) []routeShape {
+	var out []routeShape
+
+	p, err := m.parsePath(pattern)
+	if err != nil {
+		return nil
+	}
+
+	t := p.Template()
+	if p.Literal() {
+		s := routeShape{
+			mount: strings.HasSuffix(pattern, "/*"),
+		}
+
+		for _, v := range strings.Split(p.Path(), "/")[1:] {
+			if v != "" {
+				s.segments = append(s.segments, pathparser.Part{
+					Type:  pathparser.PartLiteral,
+					Value: v,
+				})
+			}
+		}
+		return []routeShape{s}
+	}
+
+	var mount bool
+	if l := len(t); l > 0 {
+		if last := t[l-1]; last.Type == pathparser.PartLiteral && last.Value == "/" {
+			t = t[:l-1]
+			mount = true
+		}
+	}
+
+	for _, alt := range expandOptionals(t) {
+		out = append(out, routeShape{
+			segments: splitSegments(alt),
+			mount:    mount,
+		})
+	}
+	return out
+}
+
+// checkPatterns finds overlapping patterns, and literal subtrees
+// losing paths to patterns
+func (m *Mux) checkPatterns(add func(RouteError)) {
+	var patterns [][]routeShape
+
+	for _, p := range m.pattern {
+		patterns = append(patterns, m.shapes(p.Pattern))
+	}
+
+	// pattern vs pattern, reported on the one with lower priority
+	for i := range patterns {
+		for j := 0; j < i; j++ {
+			if shapesOverlap(patterns[j], patterns[i]) {
+				add(RouteError{
+					Kind:    RouteOverlap,
+					Pattern: m.pattern[i].Pattern,
+					Other:   m.pattern[j].Pattern,
+				})
+			}
+		}
+	}
+
+	// literal subtrees
+	m.trie.Walk(func(_ string, v interface{}) bool {
+		n := v.(*node)
+
+		if !strings.HasSuffix(n.Pattern, "/*") || n.Pattern == "/*" {
+			// exact literals always win, and the root is
+			// expected to only get what nobody else wants
+			return false
+		}
+
+		lit := m.shapes(n.Pattern)
+		for i, s := range patterns {
+			if shapesShadow(lit[0], s) {
+				add(RouteError{
+					Kind:    RouteShadowed,
+					Pattern: n.Pattern,
+					Other:   m.pattern[i].Pattern,
+				})
+			}
+		}
+		return false
+	})
+}
+
+// shapesOverlap tells if two leaf patterns can match the same path
+func shapesOverlap(a, b []routeShape) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if !x.mount && !y.mount && segmentsOverlap(x.segments, y.segments) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func segmentsOverlap(a, b []pathparser.Part) bool {
+	for i := 0; ; i++ {
+		if i == len(a) || i == len(b) {
+			return len(a) == len(b)
+		}
+
+		x, y := a[i], b[i]
+		if x.Type == pathparser.PartCatchAll || y.Type == pathparser.PartCatchAll {
+			return true
+		} else if !segmentOverlap(x, y) {
+			return false
+		}
+	}
+}
+
+// shapesShadow tells if a pattern wins paths bellow a literal subtree
+func shapesShadow(lit routeShape, patterns []routeShape) bool {
+	n := len(lit.segments)
+
+	for _, s := range patterns {
+		for i, v := range s.segments {
+			if v.Type == pathparser.PartCatchAll {
+				return true
+			} else if i == n {
+				// longer than the literal
+				return true
+			} else if !segmentOverlap(lit.segments[i], v) {
+				break
+			}
+		}
+	}
+	return false
+}
+
+// segmentOverlap tells if two segments can match the same value
+func segmentOverlap(x, y pathparser.Part) bool {
+	if x.Type == pathparser.PartLiteral && y.Type == pathparser.PartLiteral {
+		return x.Value == y.Value
+	} else if y.Type == pathparser.PartLiteral {
+		x, y = y, x
+	}
+
+	c1, err := newSegCapture(y)
+	if err != nil {
+		return true
+	}
+
+	if x.Type == pathparser.PartLiteral {
+		return c1.match(x.Value)
+	}
+
+	c0, err := newSegCapture(x)
+	if err != nil {
+		return true
+	}
+
+	// only literal options can prove there is no overlap
+	switch {
+	case len(c0.options) > 0:
+		for _, s := range c0.options {
+			if c1.match(s) {
+				return true
+			}
+		}
+		return false
+	case len(c1.options) > 0:
+		for _, s := range c1.options {
+			if c0.match(s) {
+				return true
+			}
+		}
+		return false
+	default:
+		return true
+	}
+}
@@ -0,0 +1,78 @@
+package router
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	h := func(w http.ResponseWriter, r *http.Request) {}
+
+	m := NewRouter(nil).(*Mux).WithValidation()
+
+	m.HandleFunc("/a/{x}", h)
+	m.HandleFunc("/a/{y<int>}", h)
+	m.HandleFunc("/b/{x:one|two}", h)
+	m.HandleFunc("/b/{y:three}", h)
+	m.HandleFunc("/c/{x:1|2}", h)
+	m.HandleFunc("/c/{y<int>}", h)
+	m.HandleFunc("/d/{x}/e", h)
+	m.HandleFunc("/d/{x}/{y...}", h)
+	m.Route("/blog", func(r Router) {
+		r.HandleFunc("/", h)
+		r.MethodFunc("GET", "/{slug}", h)
+		r.MethodFunc("get", "/{slug}", h)
+		r.HandleFunc("/bad/{x", h)
+	})
+	m.HandleFunc("/{section}/{slug}", h)
+	m.HandleFunc("/f/[x", h)
+
+	var got []RouteError
+	for _, e := range m.Validate() {
+		got = append(got, *e)
+	}
+
+	expected := []RouteError{
+		{Kind: RouteSyntaxError, Pattern: "/f/[x", Column: 6},
+		{Kind: RouteOverlap, Pattern: "/c/{y<int>}", Other: "/c/{x:1|2}"},
+		{Kind: RouteOverlap, Pattern: "/a/{x}", Other: "/a/{y<int>}"},
+		{Kind: RouteOverlap, Pattern: "/d/{x}/{y...}", Other: "/d/{x}/e"},
+		{Kind: RouteOverlap, Pattern: "/{section}/{slug}", Other: "/a/{y<int>}"},
+		{Kind: RouteOverlap, Pattern: "/{section}/{slug}", Other: "/b/{x:one|two}"},
+		{Kind: RouteOverlap, Pattern: "/{section}/{slug}", Other: "/b/{y:three}"},
+		{Kind: RouteOverlap, Pattern: "/{section}/{slug}", Other: "/c/{x:1|2}"},
+		{Kind: RouteOverlap, Pattern: "/{section}/{slug}", Other: "/c/{y<int>}"},
+		{Kind: RouteOverlap, Pattern: "/{section}/{slug}", Other: "/a/{x}"},
+		{Kind: RouteShadowed, Pattern: "/blog/*", Other: "/{section}/{slug}"},
+		{Kind: RouteDuplicate, Pattern: "/blog/{slug}", Method: "GET"},
+		{Kind: RouteSyntaxError, Pattern: "/blog/bad/{x", Column: 8},
+	}
+
+	for i := range got {
+		got[i].Err = nil
+	}
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected:\n%v\ngot:\n%v", errorList(expected), errorList(got))
+	}
+}
+
+func errorList(errs []RouteError) RouteErrors {
+	out := make(RouteErrors, len(errs))
+	for i := range errs {
+		out[i] = &errs[i]
+	}
+	return out
+}
+
+func TestValidatePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("malformed pattern didn't panic")
+		}
+	}()
+
+	m := NewRouter(nil)
+	m.HandleFunc("/a/{x", func(w http.ResponseWriter, r *http.Request) {})
+}
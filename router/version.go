@@ -0,0 +1,216 @@
+package router
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.sancus.dev/web/context"
+	"go.sancus.dev/web/errors"
+)
+
+// Version describes a version of an API
+type Version struct {
+	Name       string    // as used on the path, like "v1"
+	Deprecated time.Time // when it was deprecated, if it was
+	Sunset     time.Time // when it will stop working, if known
+	Link       string    // documentation about the deprecation
+}
+
+// VersionOptions tells how the version is chosen. The path is tried
+// first, then the header, then the media type parameter on Accept.
+type VersionOptions struct {
+	Path    bool   // first segment of the path, like /v1/
+	Header  string // like "X-API-Version"
+	Param   string // like "version" on application/vnd.x+json;version=2
+	Default string // for unversioned requests, the last one if empty
+}
+
+// VersionMux routes requests to the Router of the chosen Version
+type VersionMux struct {
+	VersionOptions
+
+	mux      *Mux
+	versions []*version
+}
+
+type version struct {
+	Version
+	router *Mux
+}
+
+// Versions registers a VersionMux at the given path
+func (m *Mux) Versions(path string, opts VersionOptions) *VersionMux {
+	vm := &VersionMux{
+		VersionOptions: opts,
+		mux:            m,
+	}
+
+	m.TryHandle(subtree(path), vm)
+	return vm
+}
+
+// Version adds a Version and returns its Router
+func (vm *VersionMux) Version(v Version, fn func(Router)) Router {
+	if v.Name == "" {
+		panic(errors.New("version without name"))
+	} else if vm.get(v.Name) != nil {
+		panic(errors.New("version %q already registered", v.Name))
+	}
+
+	r := NewRouter(vm.mux.errorHandler).(*Mux)
+	r.parent = vm.mux
+	if vm.mux.validation {
+		r.WithValidation()
+	}
+
+	vm.versions = append(vm.versions, &version{
+		Version: v,
+		router:  r,
+	})
+
+	if fn != nil {
+		fn(r)
+	}
+	return r
+}
+
+// get finds a version by name, with or without the "v"
+func (vm *VersionMux) get(name string) *version {
+	name = strings.TrimPrefix(strings.ToLower(name), "v")
+
+	for _, v := range vm.versions {
+		if strings.TrimPrefix(strings.ToLower(v.Name), "v") == name {
+			return v
+		}
+	}
+	return nil
+}
+
+func (vm *VersionMux) fallback() *version {
+	if vm.Default != "" {
+		return vm.get(vm.Default)
+	} else if l := len(vm.versions); l > 0 {
+		return vm.versions[l-1]
+	}
+	return nil
+}
+
+// choose picks the version for a request, and the part of the path
+// naming it, if any
+func (vm *VersionMux) choose(r *http.Request, path string) (*version, string, error) {
+	if vm.Path && len(path) > 1 {
+		s := path[1:]
+		if i := strings.IndexRune(s, '/'); i >= 0 {
+			s = s[:i]
+		}
+
+		if v := vm.get(s); v != nil {
+			return v, "/" + s, nil
+		}
+	}
+
+	if vm.Header != "" {
+		if s := r.Header.Get(vm.Header); s != "" {
+			if v := vm.get(s); v != nil {
+				return v, "", nil
+			}
+
+			err := errors.New("%q: unknown version %q", vm.Header, s)
+			return nil, "", errors.BadRequest(err)
+		}
+	}
+
+	if vm.Param != "" {
+		for _, s := range strings.Split(r.Header.Get("Accept"), ",") {
+			_, params, err := mime.ParseMediaType(s)
+			if err != nil {
+				continue
+			} else if s, ok := params[vm.Param]; ok {
+				if v := vm.get(s); v != nil {
+					return v, "", nil
+				}
+				return nil, "", errors.ErrNotAcceptable
+			}
+		}
+	}
+
+	if v := vm.fallback(); v != nil {
+		return v, "", nil
+	}
+	return nil, "", errors.ErrNotFound
+}
+
+func (vm *VersionMux) TryServeHTTP(w http.ResponseWriter, r *http.Request) error {
+	ctx, rctx, path := context.GetRouteContextPath(r)
+
+	// the response depends on these
+	if vm.Header != "" {
+		w.Header().Add("Vary", vm.Header)
+	}
+	if vm.Param != "" {
+		w.Header().Add("Vary", "Accept")
+	}
+
+	v, prefix, err := vm.choose(r, path)
+	if err != nil {
+		return err
+	}
+
+	if prefix != "" {
+		rctx = rctx.Step(prefix, nil)
+		if rctx.RoutePath == "" {
+			rctx.RoutePath = "/"
+		}
+	}
+
+	v.setHeaders(w.Header())
+
+	ctx = context.WithRouteContext(ctx, rctx)
+	return v.router.TryServeHTTP(w, r.WithContext(ctx))
+}
+
+func (vm *VersionMux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := vm.TryServeHTTP(w, r); err != nil {
+		vm.mux.errorHandler(w, r, err)
+	}
+}
+
+// compileAll compiles the routers of every version when the
+// Mux holding them is given to a Swapper
+func (vm *VersionMux) compileAll() {
+	for _, v := range vm.versions {
+		v.router.compileAll()
+	}
+}
+
+// setHeaders announces the deprecation of a version
+// as per RFC 8594 and RFC 9745
+func (v *version) setHeaders(hdr http.Header) {
+	if !v.Deprecated.IsZero() {
+		hdr.Set("Deprecation", fmt.Sprintf("@%d", v.Deprecated.Unix()))
+	}
+
+	if !v.Sunset.IsZero() {
+		hdr.Set("Sunset", v.Sunset.UTC().Format(http.TimeFormat))
+	}
+
+	if v.Link != "" && (!v.Deprecated.IsZero() || !v.Sunset.IsZero()) {
+		hdr.Add("Link", fmt.Sprintf("<%s>; rel=\"deprecation\"", v.Link))
+	}
+}
+
+// walk goes through the routers of every version, as if they
+// were under the path
+func (vm *VersionMux) walk(prefix string, fn WalkFn) bool {
+	prefix = strings.TrimSuffix(prefix, "/*")
+
+	for _, v := range vm.versions {
+		if v.router.walk(prefix+"/"+v.Name, fn) {
+			return true
+		}
+	}
+	return false
+}
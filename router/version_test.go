@@ -0,0 +1,114 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVersions(t *testing.T) {
+	h := func(s string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, s)
+		}
+	}
+
+	deprecated := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	sunset := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	m := NewRouter(nil).(*Mux)
+	vm := m.Versions("/api", VersionOptions{
+		Path:    true,
+		Header:  "X-API-Version",
+		Param:   "version",
+		Default: "v2",
+	})
+	vm.Version(Version{Name: "v1", Deprecated: deprecated, Sunset: sunset, Link: "https://example.org/v1"}, func(r Router) {
+		r.HandleFunc("/items", h("v1"))
+		r.HandleFunc("/", h("v1 root"))
+	})
+	vm.Version(Version{Name: "v2"}, func(r Router) {
+		r.HandleFunc("/items", h("v2"))
+	})
+	vm.Version(Version{Name: "v3"}, func(r Router) {
+		r.HandleFunc("/items", h("v3"))
+	})
+
+	for _, tc := range []struct {
+		path   string
+		header [2]string
+		code   int
+		body   string
+	}{
+		{"/api/items", [2]string{}, 200, "v2"},
+		{"/api/v1/items", [2]string{}, 200, "v1"},
+		{"/api/v1", [2]string{}, 200, "v1 root"},
+		{"/api/v3/items", [2]string{"X-API-Version", "1"}, 200, "v3"},
+		{"/api/items", [2]string{"X-API-Version", "1"}, 200, "v1"},
+		{"/api/items", [2]string{"X-API-Version", "9"}, 400, ""},
+		{"/api/items", [2]string{"Accept", "application/vnd.x+json;version=3, */*;q=0.1"}, 200, "v3"},
+		{"/api/items", [2]string{"Accept", "application/vnd.x+json;version=9"}, 406, ""},
+		{"/api/v9/items", [2]string{}, 404, ""},
+	} {
+		req := httptest.NewRequest("GET", tc.path, nil)
+		if tc.header[0] != "" {
+			req.Header.Set(tc.header[0], tc.header[1])
+		}
+
+		rec := httptest.NewRecorder()
+		m.ServeHTTP(rec, req)
+
+		if rec.Code != tc.code {
+			t.Errorf("%s %q: %v instead of %v", tc.path, tc.header, rec.Code, tc.code)
+		} else if tc.body != "" && rec.Body.String() != tc.body {
+			t.Errorf("%s %q: %q instead of %q", tc.path, tc.header, rec.Body.String(), tc.body)
+		}
+
+		hdr := rec.Header()
+		if strings.HasPrefix(tc.body, "v1") {
+			if s := hdr.Get("Deprecation"); s != "@1622505600" {
+				t.Errorf("%s: Deprecation: %q", tc.path, s)
+			}
+			if s := hdr.Get("Sunset"); s != "Sat, 01 Jan 2022 00:00:00 GMT" {
+				t.Errorf("%s: Sunset: %q", tc.path, s)
+			}
+			if s := hdr.Get("Link"); s != `<https://example.org/v1>; rel="deprecation"` {
+				t.Errorf("%s: Link: %q", tc.path, s)
+			}
+		} else if s := hdr.Get("Deprecation"); s != "" {
+			t.Errorf("%s: unexpected Deprecation: %q", tc.path, s)
+		}
+	}
+
+	var paths []string
+	m.Walk(func(pattern string, h Handler) bool {
+		paths = append(paths, pattern)
+		return false
+	})
+
+	if s := fmt.Sprint(paths); s != "[/api/v1/ /api/v1/items /api/v2/items /api/v3/items]" {
+		t.Errorf("walked %s", s)
+	}
+}
+
+func TestVersionsCompiled(t *testing.T) {
+	m := NewRouter(nil).(*Mux)
+	vm := m.Versions("/api", VersionOptions{Path: true})
+	vm.Version(Version{Name: "v1"}, func(r Router) {
+		r.HandleFunc("/items", func(w http.ResponseWriter, r *http.Request) {})
+	})
+
+	NewSwapper(m)
+
+	for _, v := range vm.versions {
+		v.router.eachNode(func(n *node) bool {
+			if _, ok := n.Handler.(*rawNode); ok {
+				t.Errorf("%s%s: not compiled", v.Name, n.Pattern)
+			}
+			return false
+		})
+	}
+}
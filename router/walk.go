@@ -2,6 +2,8 @@ package router
 
 import (
 	"strings"
+
+	"go.sancus.dev/web"
 )
 
 // WalkFn is used when walking the tree. Takes the pattern and handler,
@@ -15,6 +17,49 @@ func (mux *Mux) Walk(fn WalkFn) {
 	}
 }
 
+// MethodWalkFn is used by WalkMethods. Takes the pattern, the method
+// ("*" for any), the handler as registered and the middleware applying
+// to it, outermost first, returning if iteration should be terminated.
+type MethodWalkFn func(pattern, method string, h interface{}, middleware []web.MiddlewareHandlerFunc) bool
+
+// WalkMethods calls a given function for each handler registered on
+// the tree, telling which middleware applies to each method
+func (mux *Mux) WalkMethods(fn MethodWalkFn) {
+	if fn != nil {
+		mux.walkMethods("", nil, fn)
+	}
+}
+
+func (mux *Mux) walkMethods(prefix string, chain []web.MiddlewareHandlerFunc, fn MethodWalkFn) bool {
+	var done bool
+
+	prefix = strings.TrimSuffix(prefix, "/*")
+	chain = appendChain(chain, mux.node.middleware)
+
+	mux.eachNode(func(n *node) bool {
+		if sub := n.router(); sub != nil {
+			done = sub.walkMethods(prefix+n.Pattern, chain, fn)
+			return done
+		}
+
+		for _, v := range n.handlers {
+			if fn(prefix+n.Pattern, v.method, v.handler, appendChain(chain, v.middleware)) {
+				done = true
+				break
+			}
+		}
+		return done
+	})
+
+	return done
+}
+
+// appendChain returns a new slice with both chains
+func appendChain(a, b []web.MiddlewareHandlerFunc) []web.MiddlewareHandlerFunc {
+	out := make([]web.MiddlewareHandlerFunc, 0, len(a)+len(b))
+	return append(append(out, a...), b...)
+}
+
 func walk(prefix string, n *node, fn WalkFn) bool {
 	h := n.Handler
 	pattern := prefix + n.Pattern
@@ -43,7 +88,7 @@ func (mux *Mux) walk(prefix string, fn WalkFn) bool {
 	if !done {
 		// re
 		for _, n := range mux.pattern {
-			if walk(prefix, n, fn) {
+			if walk(prefix, n.node, fn) {
 				return true // done
 			}
 		}
@@ -0,0 +1,126 @@
+package sitemap
+
+import (
+	"compress/gzip"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"strconv"
+
+	"go.sancus.dev/web/errors"
+	"go.sancus.dev/web/qlist"
+)
+
+// TryServeHTTP serves the sitemap, or a sitemap index when there are
+// too many URLs for a single file. The parts of an index are served
+// at the same path, using the page query parameter
+func (s *Sitemap) TryServeHTTP(rw http.ResponseWriter, req *http.Request) error {
+	switch req.Method {
+	case "GET", "HEAD":
+		urls, err := s.URLs(req)
+		if err != nil {
+			return err
+		}
+
+		var v interface{}
+
+		max := s.maxURLs()
+		if page := req.URL.Query().Get("page"); page != "" {
+			n, err := strconv.Atoi(page)
+			if err != nil || n < 1 || (n-1)*max >= len(urls) {
+				return errors.ErrNotFound
+			}
+
+			end := n * max
+			if end > len(urls) {
+				end = len(urls)
+			}
+			v = newURLSet(urls[(n-1)*max : end])
+		} else if len(urls) > max {
+			v = s.newIndex(req, (len(urls)+max-1)/max)
+		} else {
+			v = newURLSet(urls)
+		}
+
+		// encoding
+		enc, ok := qlist.BestEncoding([]string{"gzip"}, req.Header.Get("Accept-Encoding"))
+		if !ok {
+			return errors.ErrNotAcceptable
+		}
+
+		rw.Header().Set("Content-Type", "application/xml; charset=utf-8")
+		rw.Header().Add("Vary", "Accept-Encoding")
+		if enc == "gzip" {
+			rw.Header().Set("Content-Encoding", enc)
+		}
+
+		if req.Method == "HEAD" {
+			return nil
+		}
+
+		return render(rw, enc, v)
+	default:
+		return errors.MethodNotAllowed(req.Method, "GET", "HEAD")
+	}
+}
+
+func (s *Sitemap) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	if err := s.TryServeHTTP(rw, req); err != nil {
+		errors.HandleError(rw, req, err)
+	}
+}
+
+func newURLSet(urls []URL) *urlSet {
+	set := &urlSet{
+		XMLNS: Namespace,
+		URLs:  urls,
+	}
+
+	for _, u := range urls {
+		if len(u.Alternates) > 0 {
+			set.XHTML = XHTMLNamespace
+			break
+		}
+	}
+
+	return set
+}
+
+func (s *Sitemap) newIndex(req *http.Request, pages int) *sitemapIndex {
+	index := &sitemapIndex{
+		XMLNS: Namespace,
+	}
+
+	loc := s.baseURL(req) + req.URL.Path + "?page="
+	for i := 1; i <= pages; i++ {
+		index.Sitemaps = append(index.Sitemaps, sitemapEntry{
+			Loc: loc + strconv.Itoa(i),
+		})
+	}
+
+	return index
+}
+
+func render(w io.Writer, enc string, v interface{}) error {
+	if enc == "gzip" {
+		zw := gzip.NewWriter(w)
+		if err := render(zw, "", v); err != nil {
+			zw.Close()
+			return err
+		}
+		return zw.Close()
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	e := xml.NewEncoder(w)
+	e.Indent("", "  ")
+	if err := e.Encode(v); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,258 @@
+// Package sitemap generates sitemap.xml files from the PageInfo
+// provided by the handlers of a router.Mux
+package sitemap
+
+import (
+	"encoding/xml"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.sancus.dev/web"
+	"go.sancus.dev/web/router"
+)
+
+const (
+	// MaxURLs is the limit of URLs on a single sitemap file
+	MaxURLs = 50000
+
+	// Namespace of the sitemap protocol
+	Namespace = "http://www.sitemaps.org/schemas/sitemap/0.9"
+	// XHTMLNamespace is used for the hreflang alternates
+	XHTMLNamespace = "http://www.w3.org/1999/xhtml"
+)
+
+// Lister is implemented by handlers of routes with captures to
+// enumerate the pages they serve. Pages are PageInfo objects and
+// must implement web.PageInfoLocation or web.PageInfoCanonical
+type Lister interface {
+	Pages(*http.Request) ([]interface{}, error)
+}
+
+// PageInfoAlternate can tell the Path of the page on a given language,
+// used for the hreflang alternates. Languages without one are left out
+type PageInfoAlternate interface {
+	Alternate(lang string) string
+}
+
+// Sitemap walks a Mux and serves the pages it finds
+type Sitemap struct {
+	mux *router.Mux
+
+	BaseURL string // scheme://host, taken from the request if empty
+	MaxURLs int    // per file, MaxURLs if zero
+}
+
+// New creates a Sitemap of the given Mux
+func New(m *router.Mux) *Sitemap {
+	return &Sitemap{
+		mux: m,
+	}
+}
+
+// URL is an entry of a sitemap
+type URL struct {
+	Loc        string      `xml:"loc"`
+	LastMod    string      `xml:"lastmod,omitempty"`
+	ChangeFreq string      `xml:"changefreq,omitempty"`
+	Priority   string      `xml:"priority,omitempty"`
+	Alternates []Alternate `xml:"xhtml:link,omitempty"`
+}
+
+// Alternate is a link to the same page on another language
+type Alternate struct {
+	Rel      string `xml:"rel,attr"`
+	HrefLang string `xml:"hreflang,attr"`
+	Href     string `xml:"href,attr"`
+}
+
+type urlSet struct {
+	XMLName xml.Name `xml:"urlset"`
+	XMLNS   string   `xml:"xmlns,attr"`
+	XHTML   string   `xml:"xmlns:xhtml,attr,omitempty"`
+	URLs    []URL    `xml:"url"`
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name       `xml:"sitemapindex"`
+	XMLNS    string         `xml:"xmlns,attr"`
+	Sitemaps []sitemapEntry `xml:"sitemap"`
+}
+
+type sitemapEntry struct {
+	Loc string `xml:"loc"`
+}
+
+// URLs collects the entries of the sitemap. Literal routes are included
+// when their GET handler implements web.RouterPageInfo and reports the page
+// as existing, and any route when the handler implements Lister
+func (s *Sitemap) URLs(r *http.Request) ([]URL, error) {
+	var out []URL
+
+	base := s.baseURL(r)
+	seen := make(map[string]bool)
+
+	add := func(path string, info interface{}) {
+		if u, ok := newURL(base, path, info); ok && !seen[u.Loc] {
+			seen[u.Loc] = true
+			out = append(out, u)
+		}
+	}
+
+	err := s.collect(r, s.mux.Inspect(), add)
+	return out, err
+}
+
+func (s *Sitemap) collect(r *http.Request, ri *router.RouteInfo, add func(string, interface{})) error {
+	if ri.Router {
+		for _, sub := range ri.Routes {
+			if err := s.collect(r, sub, add); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	path := ri.Path()
+
+	for _, h := range ri.Handlers {
+		if h.Method != "*" && h.Method != "GET" {
+			continue
+		}
+
+		switch v := h.Handler.(type) {
+		case Lister:
+			pages, err := v.Pages(r)
+			if err != nil {
+				return err
+			}
+
+			for _, info := range pages {
+				add("", info)
+			}
+		case web.RouterPageInfo:
+			if !literal(path) {
+				continue
+			}
+
+			req, err := http.NewRequestWithContext(r.Context(), "GET", path, nil)
+			if err != nil {
+				return err
+			}
+			req.Host = r.Host
+
+			if info, ok := v.PageInfo(req); ok {
+				add(path, info)
+			}
+		}
+	}
+
+	return nil
+}
+
+// literal tells if a route pattern matches a single path
+func literal(pattern string) bool {
+	return !strings.ContainsAny(pattern, "{}[]*")
+}
+
+func newURL(base, path string, info interface{}) (URL, bool) {
+	var u URL
+
+	if v, ok := info.(web.PageInfoCanonical); ok {
+		u.Loc = v.Canonical()
+	}
+	if u.Loc == "" {
+		if v, ok := info.(web.PageInfoLocation); ok {
+			u.Loc = v.Location()
+		}
+	}
+	if u.Loc == "" {
+		if path == "" {
+			return u, false
+		}
+		u.Loc = path
+	}
+	u.Loc = absURL(base, u.Loc)
+
+	if v, ok := info.(web.PageInfoLastModified); ok {
+		if t := v.LastModified(); !t.IsZero() {
+			u.LastMod = t.UTC().Format(time.RFC3339)
+		}
+	}
+
+	if v, ok := info.(web.PageInfoChangeFrequency); ok {
+		u.ChangeFreq = strings.ToLower(v.ChangeFrequency())
+	}
+
+	if v, ok := priority(info); ok {
+		if p := v.Priority(); p >= 0 && p <= 1 {
+			u.Priority = strconv.FormatFloat(float64(p), 'f', -1, 32)
+		}
+	}
+
+	if v, ok := info.(web.PageInfoLanguage); ok {
+		langs := v.Language()
+		alt, _ := info.(PageInfoAlternate)
+
+		// a single language needs no alternates, and
+		// languages without a path are left out
+		for _, lang := range langs {
+			if len(langs) < 2 || alt == nil {
+				break
+			}
+
+			s := alt.Alternate(lang)
+			if s == "" {
+				continue
+			}
+
+			u.Alternates = append(u.Alternates, Alternate{
+				Rel:      "alternate",
+				HrefLang: lang,
+				Href:     absURL(base, s),
+			})
+		}
+	}
+
+	return u, true
+}
+
+// priority checks the info of wrappers like router.PageInfo,
+// which always have a Priority() even if the page doesn't
+func priority(info interface{}) (web.PageInfoPriority, bool) {
+	if v, ok := info.(interface {
+		Info() interface{}
+	}); ok {
+		info = v.Info()
+	}
+
+	v, ok := info.(web.PageInfoPriority)
+	return v, ok
+}
+
+func absURL(base, path string) string {
+	if strings.HasPrefix(path, "/") {
+		return base + path
+	}
+	return path
+}
+
+func (s *Sitemap) baseURL(r *http.Request) string {
+	if s.BaseURL != "" {
+		return strings.TrimSuffix(s.BaseURL, "/")
+	}
+
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host
+}
+
+func (s *Sitemap) maxURLs() int {
+	if s.MaxURLs > 0 && s.MaxURLs < MaxURLs {
+		return s.MaxURLs
+	}
+	return MaxURLs
+}
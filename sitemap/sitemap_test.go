@@ -0,0 +1,209 @@
+package sitemap
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.sancus.dev/web/router"
+)
+
+type page struct {
+	loc   string
+	langs []string
+}
+
+func (p page) Location() string        { return p.loc }
+func (p page) ChangeFrequency() string { return "Daily" }
+func (p page) Priority() float32       { return 0.8 }
+func (p page) Language() []string      { return p.langs }
+func (p page) Alternate(lang string) string {
+	return "/" + lang + p.loc
+}
+func (p page) LastModified() time.Time {
+	return time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)
+}
+
+type pageHandler struct {
+	page
+}
+
+func (h pageHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {}
+
+func (h pageHandler) PageInfo(r *http.Request) (interface{}, bool) {
+	return h.page, r.URL.Path == h.loc
+}
+
+type listHandler []interface{}
+
+func (h listHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {}
+
+func (h listHandler) Pages(r *http.Request) ([]interface{}, error) {
+	return h, nil
+}
+
+func newTestSitemap() *Sitemap {
+	m := router.NewRouter(nil).(*router.Mux)
+
+	m.Handle("/", pageHandler{page{loc: "/"}})
+	m.Handle("/about", pageHandler{page{loc: "/about", langs: []string{"en", "es"}}})
+	m.Handle("/api", http.NotFoundHandler())
+	m.Route("/blog", func(r router.Router) {
+		r.Handle("/{slug}", listHandler{page{loc: "/blog/a"}, page{loc: "/blog/b"}})
+	})
+
+	return New(m)
+}
+
+func TestURLs(t *testing.T) {
+	s := newTestSitemap()
+	s.BaseURL = "https://example.org/"
+
+	urls, err := s.URLs(httptest.NewRequest("GET", "/sitemap.xml", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var locs []string
+	for _, u := range urls {
+		locs = append(locs, u.Loc)
+	}
+
+	expected := "https://example.org/ https://example.org/about https://example.org/blog/a https://example.org/blog/b"
+	if s := strings.Join(locs, " "); s != expected {
+		t.Fatalf("%q instead of %q", s, expected)
+	}
+
+	u := urls[1]
+	if u.ChangeFreq != "daily" || u.Priority != "0.8" || u.LastMod != "2021-01-02T03:04:05Z" {
+		t.Errorf("%q: bad attributes %#v", u.Loc, u)
+	}
+
+	if len(u.Alternates) != 2 || u.Alternates[1].Href != "https://example.org/es/about" {
+		t.Errorf("%q: bad alternates %#v", u.Loc, u.Alternates)
+	}
+}
+
+func TestAlternates(t *testing.T) {
+	m := router.NewRouter(nil).(*router.Mux)
+	m.Handle("/quarter", listHandler{quarterPage{page{loc: "/quarter", langs: []string{"en", "es"}}}})
+
+	urls, err := New(m).URLs(httptest.NewRequest("GET", "http://example.org/sitemap.xml", nil))
+	if err != nil {
+		t.Fatal(err)
+	} else if len(urls) != 1 {
+		t.Fatalf("%v urls", len(urls))
+	}
+
+	u := urls[0]
+	if len(u.Alternates) != 1 || u.Alternates[0].Href != "http://example.org/en/quarter" {
+		t.Errorf("%q: bad alternates %#v", u.Loc, u.Alternates)
+	}
+}
+
+func TestServeIndex(t *testing.T) {
+	s := newTestSitemap()
+	s.MaxURLs = 3
+
+	req := httptest.NewRequest("GET", "http://example.org/sitemap.xml", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if enc := rec.Header().Get("Content-Encoding"); enc != "gzip" {
+		t.Fatalf("Content-Encoding %q", enc)
+	}
+
+	zr, err := gzip.NewReader(rec.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, s := range []string{
+		"<sitemapindex",
+		"<loc>http://example.org/sitemap.xml?page=2</loc>",
+	} {
+		if !strings.Contains(string(b), s) {
+			t.Errorf("%q missing in %s", s, b)
+		}
+	}
+
+	// second part
+	rec = httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest("GET", "http://example.org/sitemap.xml?page=2", nil))
+
+	body := rec.Body.String()
+	if !strings.Contains(body, "<loc>http://example.org/blog/b</loc>") ||
+		strings.Contains(body, "<loc>http://example.org/about</loc>") {
+		t.Errorf("bad second part: %s", body)
+	}
+
+	rec = httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest("GET", "http://example.org/sitemap.xml?page=3", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("page 3: %v", rec.Code)
+	}
+}
+
+type zeroPage struct {
+	page
+}
+
+func (p zeroPage) Priority() float32 { return 0 }
+
+type quarterPage struct {
+	page
+}
+
+func (p quarterPage) Priority() float32 { return 0.75 }
+
+// Alternate only knows the English path
+func (p quarterPage) Alternate(lang string) string {
+	if lang != "en" {
+		return ""
+	}
+	return p.page.Alternate(lang)
+}
+
+type plainPage struct{}
+
+func (plainPage) ServeHTTP(w http.ResponseWriter, r *http.Request) {}
+
+func (plainPage) PageInfo(r *http.Request) (interface{}, bool) {
+	return struct{}{}, true
+}
+
+func TestPriority(t *testing.T) {
+	m := router.NewRouter(nil).(*router.Mux)
+	m.Handle("/zero", listHandler{zeroPage{page{loc: "/zero"}}})
+	m.Handle("/plain", plainPage{})
+	m.Handle("/quarter", listHandler{quarterPage{page{loc: "/quarter", langs: []string{"en", "es"}}}})
+
+	urls, err := New(m).URLs(httptest.NewRequest("GET", "http://example.org/sitemap.xml", nil))
+	if err != nil {
+		t.Fatal(err)
+	} else if len(urls) != 3 {
+		t.Fatalf("%v urls", len(urls))
+	}
+
+	for _, u := range urls {
+		switch {
+		case strings.HasSuffix(u.Loc, "/zero") && u.Priority != "0":
+			t.Errorf("%q: explicit priority %q", u.Loc, u.Priority)
+		case strings.HasSuffix(u.Loc, "/quarter") && u.Priority != "0.75":
+			t.Errorf("%q: rounded priority %q", u.Loc, u.Priority)
+		case strings.HasSuffix(u.Loc, "/plain") && u.Priority != "":
+			t.Errorf("%q: unexpected priority %q", u.Loc, u.Priority)
+		}
+	}
+}